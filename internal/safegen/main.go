@@ -0,0 +1,181 @@
+// Command safegen writes safeclient.go, which wraps every exported method of
+// Client in a SafeClient method holding the SafeClient lock.
+//
+// It is run from the module root by go generate, and reads every non test
+// file of the package.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// File written by safegen
+const output = "safeclient.go"
+
+// Methods written by hand on SafeClient
+var skipped = map[string]bool{"Close": true, "Do": true}
+
+func main() {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return info.Name() != output && !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pkg, ok := pkgs["nutclient"]
+	if !ok || len(pkgs) != 1 {
+		log.Fatal("safegen: expected the nutclient package only")
+	}
+
+	var names []string
+	for name := range pkg.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var body bytes.Buffer
+	imports := make(map[string]bool)
+	for _, name := range names {
+		file := pkg.Files[name]
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !isclientmethod(fn) || skipped[fn.Name.Name] {
+				continue
+			}
+			writemethod(&body, fset, fn)
+			addimports(imports, file, fn.Type)
+		}
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by internal/safegen; DO NOT EDIT.\n\n")
+	out.WriteString("package nutclient\n")
+	if len(imports) > 0 {
+		var paths []string
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		out.WriteString("\nimport (\n")
+		for _, path := range paths {
+			out.WriteString(strconv.Quote(path) + "\n")
+		}
+		out.WriteString(")\n")
+	}
+	out.Write(body.Bytes())
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(output, source, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Return true if fn is an exported method of *Client
+func isclientmethod(fn *ast.FuncDecl) bool {
+	if fn.Recv == nil || !fn.Name.IsExported() {
+		return false
+	}
+	star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && ident.Name == "Client"
+}
+
+// Record the import paths of the packages used in the signature typ,
+// as imported by file
+func addimports(imports map[string]bool, file *ast.File, typ *ast.FuncType) {
+	ast.Inspect(typ, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		for _, spec := range file.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := path[strings.LastIndex(path, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			if name == ident.Name {
+				imports[path] = true
+				return true
+			}
+		}
+		log.Fatalf("safegen: cannot find the import of %s", ident.Name)
+		return true
+	})
+}
+
+// Write the SafeClient wrapper of fn
+func writemethod(out *bytes.Buffer, fset *token.FileSet, fn *ast.FuncDecl) {
+	var params, args []string
+	n := 0
+	for _, field := range fn.Type.Params.List {
+		typ := node(fset, field.Type)
+		names := field.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("arg%d", n))}
+		}
+		for _, name := range names {
+			n++
+			params = append(params, name.Name+" "+typ)
+			if _, variadic := field.Type.(*ast.Ellipsis); variadic {
+				args = append(args, name.Name+"...")
+			} else {
+				args = append(args, name.Name)
+			}
+		}
+	}
+
+	var types []string
+	if fn.Type.Results != nil {
+		for _, field := range fn.Type.Results.List {
+			typ := node(fset, field.Type)
+			for i := 0; i < len(field.Names) || i == 0; i++ {
+				types = append(types, typ)
+			}
+		}
+	}
+	results := strings.Join(types, ", ")
+	if len(types) > 1 {
+		results = "(" + results + ")"
+	}
+
+	call := fmt.Sprintf("s.client.%s(%s)", fn.Name.Name, strings.Join(args, ", "))
+	if fn.Type.Results != nil {
+		call = "return " + call
+	}
+
+	fmt.Fprintf(out, "\n// %s calls Client.%s while holding the lock\n", fn.Name.Name, fn.Name.Name)
+	fmt.Fprintf(out, "func (s *SafeClient) %s(%s) %s {\n", fn.Name.Name, strings.Join(params, ", "), results)
+	fmt.Fprintf(out, "s.mu.Lock()\ndefer s.mu.Unlock()\n%s\n}\n", call)
+}
+
+// Return the source of n
+func node(fset *token.FileSet, n ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, n); err != nil {
+		log.Fatal(err)
+	}
+	return buf.String()
+}
@@ -2,722 +2,4133 @@
 package nutclient
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
 	"net"
 	"net/textproto"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
+// ErrConnectionClosed is returned by calls made on a client whose
+// connection has been closed, for instance after an idle timeout.
+var ErrConnectionClosed = errors.New("Connection closed")
+
+// ErrUnknownVariable is returned when the ups does not support the requested variable
+var ErrUnknownVariable = errors.New("ERR VAR-NOT-SUPPORTED")
+
+// ErrVarNotSupported is the NUT protocol name of ErrUnknownVariable
+var ErrVarNotSupported = ErrUnknownVariable
+
+// ErrAccessDenied is returned when the user is not allowed to perform the command
+var ErrAccessDenied = errors.New("ERR ACCESS-DENIED")
+
+// ErrUnknownUPS is returned when the ups is not declared on the server
+var ErrUnknownUPS = errors.New("ERR UNKNOWN-UPS")
+
+// ErrCmdNotSupported is returned when the ups does not support the instant command
+var ErrCmdNotSupported = errors.New("ERR CMD-NOT-SUPPORTED")
+
+// ErrAlreadyLoggedIn is returned when LOGIN is sent twice on the same connection
+var ErrAlreadyLoggedIn = errors.New("ERR ALREADY-LOGGED-IN")
+
+// ErrDriverNotConnected is returned when the server cannot reach the ups driver
+var ErrDriverNotConnected = errors.New("ERR DRIVER-NOT-CONNECTED")
+
+// ErrDataStale is returned when the driver has not refreshed the ups data recently
+var ErrDataStale = errors.New("ERR DATA-STALE")
+
+// ErrAlreadySetPassword is returned when PASSWORD is sent twice on the same connection
+var ErrAlreadySetPassword = errors.New("ERR ALREADY-SET-PASSWORD")
+
+// Errors returned for the ERR codes of the NUT protocol
+var responseErrors = map[string]error{
+	"VAR-NOT-SUPPORTED":    ErrVarNotSupported,
+	"ACCESS-DENIED":        ErrAccessDenied,
+	"UNKNOWN-UPS":          ErrUnknownUPS,
+	"CMD-NOT-SUPPORTED":    ErrCmdNotSupported,
+	"ALREADY-LOGGED-IN":    ErrAlreadyLoggedIn,
+	"DRIVER-NOT-CONNECTED": ErrDriverNotConnected,
+	"DATA-STALE":           ErrDataStale,
+	"ALREADY-SET-PASSWORD": ErrAlreadySetPassword,
+}
+
+// ErrValueOutOfRange is returned when a value is outside the range allowed by the driver
+var ErrValueOutOfRange = errors.New("Value out of range")
+
+// ErrShutdownTimerInactive is returned when waiting on a shutdown timer that is not running
+var ErrShutdownTimerInactive = errors.New("Shutdown timer not active")
+
 // A Client represents a client connection to a nut server.
+//
+// A Client is not safe for concurrent use by multiple goroutines: although
+// single commands are sequenced on the wire, session changes (StartTLS, Auth,
+// Login) and methods issuing several commands are not synchronized. Use a
+// SafeClient to share a connection between goroutines.
 type Client struct {
-	Text       *textproto.Conn
-	conn       net.Conn
-	tls        bool
-	serverName string
-	upsName    string
+	Text          *textproto.Conn
+	conn          net.Conn
+	tls           bool
+	serverName    string
+	upsName       string
+	idleTimeout   time.Duration
+	idleTimer     *time.Timer
+	mutex         sync.Mutex
+	closed        bool
+	aborted       bool
+	location      *time.Location
+	dateFormat    string
+	timeFormat    string
+	pollInterval  time.Duration
+	ctx           context.Context
+	tlsConfig     *tls.Config
+	autoTLS       bool
+	dialTimeout   time.Duration
+	readTimeout   time.Duration
+	logger        Logger
+	readDeadline  time.Time
+	writeDeadline time.Time
+	address       string
+	directTLS     bool
+	startedTLS    bool
+	login         string
+	password      string
+	retryPolicy   *RetryPolicy
+	reconnecting  bool
 }
 
-// The addr must include a port, as in "nutsrv.example.com:3493".
-func Dial(address string) (*Client, error) {
-	conn, err := net.Dial("tcp", address)
-	if err != nil {
-		return nil, err
-	}
-	host, _, _ := net.SplitHostPort(address)
-	return NewClient(conn, host)
+// A RetryPolicy tells how a client reconnects after an io error
+type RetryPolicy struct {
+	// Number of connection attempts, at least 1
+	MaxAttempts int
+	// Delay before the second attempt, doubled after each failed attempt
+	BaseDelay time.Duration
+	// Upper bound of a random delay added to each wait
+	Jitter time.Duration
 }
 
-// NewClient returns a new Client instance
-func NewClient(conn net.Conn, host string) (*Client, error) {
-	text := textproto.NewConn(conn)
-	c := &Client{Text: text, conn: conn, serverName: host, tls: false, upsName: ""}
-	_, c.tls = conn.(*tls.Conn)
-	return c, nil
+// A Logger receives log messages from the client
+type Logger interface {
+	Log(level string, message string, fields map[string]interface{})
 }
 
-// Close closes the connection.
-func (c *Client) Close() error {
-	return c.Text.Close()
-}
+// A ClientOption configures a Client at creation time
+type ClientOption func(*Client)
 
-// Sends a command and returns the response
-func (c *Client) cmd(format string) (string, error) {
+// Option is the former name of ClientOption
+type Option = ClientOption
 
-	err := c.Text.PrintfLine("%s", format)
-	if err != nil {
-		return "", err
+// WithTLSConfig sets the TLS configuration used by STARTTLS
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.tlsConfig = config
 	}
-	response, err := c.Text.ReadLine()
+}
 
-	if err != nil {
-		return "", err
+// WithAutoStartTLS makes the client send STARTTLS as soon as it is connected
+func WithAutoStartTLS(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.autoTLS = enabled
 	}
-	retcode, retarg, _ := strings.Cut(response, " ")
+}
 
-	if strings.EqualFold(retcode, "OK") {
-		return retarg, nil
-	} else {
-		return "", errors.New(response)
+// WithDialTimeout bounds the duration of the connection attempt
+func WithDialTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.dialTimeout = d
 	}
 }
 
-// Get a specific data from current ups
-func (c *Client) GetData(format string) (string, error) {
+// WithReadTimeout bounds the time spent waiting for each server response.
+// After a timeout the connection is closed, as the late response would be read
+// by the next command ; further calls return ErrConnectionClosed unless
+// WithAutoReconnect is set.
+func WithReadTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.readTimeout = d
+	}
+}
 
-	if len([]rune(c.upsName)) == 0 {
-		return "", errors.New("No UPS defined, use LOGIN first")
+// WithLogger sends client log messages to l.
+// Every command sent and response received is logged at "debug" level,
+// with passwords hidden.
+func WithLogger(l Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithAutoReconnect makes the client re-dial the server after an io error,
+// then authenticate, start TLS and log in to the ups again as it did before.
+// The call that hit the error still returns it ; further calls use the new
+// connection. Only clients created by Dial functions can reconnect.
+func WithAutoReconnect(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = &policy
 	}
+}
 
-	if len(format) == 0 {
-		return "", errors.New("Variable cannot be empty")
+// WithIdleTimeout makes the client disconnect after d without any successful command
+func WithIdleTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.idleTimeout = d
 	}
+}
 
-	command := "GET VAR " + c.upsName + " " + format
+// WithTimeLocation sets the time zone of the ups clock (UTC by default)
+func WithTimeLocation(loc *time.Location) ClientOption {
+	return func(c *Client) {
+		c.location = loc
+	}
+}
 
-	err := c.Text.PrintfLine("%s", command)
+// WithTimeZone sets the time zone of the ups clock, same as WithTimeLocation
+func WithTimeZone(loc *time.Location) ClientOption {
+	return WithTimeLocation(loc)
+}
 
-	if err != nil {
-		return "", err
+// WithClockFormats sets the layouts of ups.date and ups.time used by SystemTime,
+// SetSystemTime, UpsDate and SetUpsTime ("01/02/2006" and "15:04:05" by default).
+// The date layout is also used to write battery dates.
+func WithClockFormats(dateFormat string, timeFormat string) ClientOption {
+	return func(c *Client) {
+		c.dateFormat = dateFormat
+		c.timeFormat = timeFormat
 	}
-	response, err := c.Text.ReadLine()
+}
 
-	if err != nil {
-		return "", err
+// WithShutdownPollInterval sets how often WaitForShutdown reads the shutdown timer (1 second by default)
+func WithShutdownPollInterval(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.pollInterval = d
 	}
-	retcode, retarg, _ := strings.Cut(response, " ")
+}
 
-	if strings.EqualFold(retcode, "VAR") {
-		_, retarg, _ = strings.Cut(response, "\"")
-		retarg = strings.ReplaceAll(retarg, "\"", "")
-		return retarg, nil
-	} else {
-		return "", errors.New(response)
+// WithContext bounds the connection attempt and all further commands by ctx.
+// Deadline and cancellation of ctx are applied to the underlying connection.
+// A command interrupted by ctx closes the connection, as its late response
+// would be read by the next command ; further calls return ErrConnectionClosed,
+// even after SetContext.
+func WithContext(ctx context.Context) ClientOption {
+	return func(c *Client) {
+		c.ctx = ctx
 	}
 }
 
-// Get a multiline data response
-func (c *Client) getmultilinesdata(command string) ([]string, error) {
+// The addr must include a port, as in "nutsrv.example.com:3493".
+func Dial(address string) (*Client, error) {
+	return DialContext(context.Background(), address)
+}
 
-	var retslice []string
-	if len(command) == 0 {
-		return nil, errors.New("Variable cannot be empty")
+// DialContext is like DialWithOptions, with ctx bounding the connection attempt only.
+// Use WithContext or SetContext to bound further commands.
+func DialContext(ctx context.Context, address string, opts ...ClientOption) (*Client, error) {
+	return dial(ctx, address, nil, opts...)
+}
+
+// DialWithOptions connects to address and applies opts to the new Client
+func DialWithOptions(address string, opts ...ClientOption) (*Client, error) {
+	return dial(optionscontext(opts), address, nil, opts...)
+}
+
+// Return the context set by WithContext in opts, or the background context
+func optionscontext(opts []ClientOption) context.Context {
+	settings := &Client{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(settings)
 	}
+	return settings.ctx
+}
 
-	err := c.Text.PrintfLine("%s", command)
+// Connect to address and apply opts to the new Client. ctx bounds the
+// connection attempt. When config is not nil, the connection is wrapped in TLS.
+func dial(ctx context.Context, address string, config *tls.Config, opts ...ClientOption) (*Client, error) {
+	settings := &Client{}
+	for _, opt := range opts {
+		opt(settings)
+	}
 
+	var conn net.Conn
+	var err error
+	netdialer := &net.Dialer{Timeout: settings.dialTimeout}
+	if config != nil {
+		dialer := &tls.Dialer{NetDialer: netdialer, Config: config}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		conn, err = netdialer.DialContext(ctx, "tcp", address)
+	}
 	if err != nil {
 		return nil, err
 	}
-	response, err := c.Text.ReadLine()
 
+	if config != nil {
+		// Keep the dial configuration for reconnections
+		opts = append(opts[:len(opts):len(opts)], WithTLSConfig(config))
+	}
+	host, _, _ := net.SplitHostPort(address)
+	c, err := NewClientWithOptions(conn, host, opts...)
 	if err != nil {
 		return nil, err
 	}
-	retcode, _, _ := strings.Cut(response, " ")
+	c.address = address
+	c.directTLS = config != nil
+	return c, nil
+}
 
-	if strings.EqualFold(retcode, "BEGIN") {
-		exitloop := false
-		for !exitloop {
-			response, err := c.Text.ReadLine()
+// DialTLS connects to a nut server whose listener is wrapped in TLS, for
+// servers that do not support the STARTTLS upgrade, and applies opts to the
+// new Client. With WithAutoReconnect, reconnections are made over TLS too.
+func DialTLS(address string, config *tls.Config, opts ...ClientOption) (*Client, error) {
+	if config == nil {
+		config = &tls.Config{}
+	}
+	return dial(optionscontext(opts), address, config, opts...)
+}
 
-			if err != nil {
-				return nil, err
-			}
-			retcode, _, _ := strings.Cut(response, " ")
-			if strings.EqualFold(retcode, "END") {
-				exitloop = true
-			} else {
-				retslice = append(retslice, response)
-			}
-		}
-	} else {
-		return nil, errors.New(response)
+// DialTLSContext is like DialTLS, with ctx bounding the connection attempt only.
+// Use WithContext or SetContext to bound further commands.
+func DialTLSContext(ctx context.Context, address string, config *tls.Config, opts ...ClientOption) (*Client, error) {
+	if config == nil {
+		config = &tls.Config{}
 	}
-	return retslice, nil
+	return dial(ctx, address, config, opts...)
 }
 
-// StartTLS sends the STARTTLS command and encrypts all further communication.
-func (c *Client) StartTLS(configtls *tls.Config) error {
+// NewClient returns a new Client instance
+func NewClient(conn net.Conn, host string) (*Client, error) {
+	return NewClientWithOptions(conn, host)
+}
 
-	_, err := c.cmd("STARTTLS")
-	if err != nil {
-		return err
+// NewClientWithOptions returns a new Client instance configured with opts
+func NewClientWithOptions(conn net.Conn, host string, opts ...ClientOption) (*Client, error) {
+	text := textproto.NewConn(conn)
+	c := &Client{Text: text, conn: conn, serverName: host, tls: false, upsName: "", location: time.UTC,
+		dateFormat: "01/02/2006", timeFormat: "15:04:05", pollInterval: time.Second,
+		ctx: context.Background()}
+	_, c.tls = conn.(*tls.Conn)
+
+	for _, opt := range opts {
+		opt(c)
 	}
-	c.conn = tls.Client(c.conn, configtls)
-	c.Text = textproto.NewConn(c.conn)
-	c.tls = true
-	return err
-}
 
-// Perform Auth on nut server
-func (c *Client) Auth(login string, password string) error {
+	c.log("info", "connected", map[string]interface{}{"server": host, "tls": c.tls})
 
-	_, err := c.cmd("USERNAME " + login)
-	if err != nil {
-		return errors.New("ERROR : Bad Login " + err.Error())
+	if c.autoTLS && !c.tls {
+		err := c.StartTLS(c.tlsConfig)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
 	}
 
-	_, err = c.cmd("PASSWORD " + password)
-	if err != nil {
-		return errors.New("ERROR : Bad Password " + err.Error())
+	if c.idleTimeout > 0 {
+		c.idleTimer = time.AfterFunc(c.idleTimeout, func() {
+			c.Disconnect()
+		})
 	}
+	return c, nil
+}
 
-	return nil
+// A StdLogger is a Logger writing messages to a log.Logger, one line per message
+type StdLogger struct {
+	logger *log.Logger
 }
 
-// Perform Login command to select current ups
-func (c *Client) Login(upsName string) error {
+// NewStdLogger returns a StdLogger writing to l, or to the standard logger when l is nil
+func NewStdLogger(l *log.Logger) *StdLogger {
+	if l == nil {
+		l = log.Default()
+	}
+	return &StdLogger{logger: l}
+}
 
-	_, err := c.cmd("LOGIN " + upsName)
-	if err != nil {
-		return err
+// Log writes the level, the message and the fields sorted by name
+func (l *StdLogger) Log(level string, message string, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	c.upsName = upsName
-	return nil
+	var line strings.Builder
+	line.WriteString("[" + strings.ToUpper(level) + "] " + message)
+	for _, key := range keys {
+		if value, ok := fields[key].(string); ok {
+			fmt.Fprintf(&line, " %s=%q", key, value)
+		} else {
+			fmt.Fprintf(&line, " %s=%v", key, fields[key])
+		}
+	}
+	l.logger.Print(line.String())
 }
 
-// Perform Logout command.
-func (c *Client) Logout() error {
-
-	_, err := c.cmd("LOGOUT")
-	if err != nil {
-		return err
+// Send a message to the configured logger, if any
+func (c *Client) log(level string, message string, fields map[string]interface{}) {
+	if c.logger != nil {
+		c.logger.Log(level, message, fields)
 	}
-	return nil
 }
 
-// Return true if current ups is online
-func (c *Client) IsOnline() (bool, error) {
-	online := false
-	if len([]rune(c.upsName)) == 0 {
-		return false, errors.New("No UPS defined, use LOGIN first")
+// Close closes the connection.
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	c.closed = true
+	c.aborted = false
+	if c.idleTimer != nil {
+		c.idleTimer.Stop()
 	}
+	c.mutex.Unlock()
 
-	result, err := c.GetData("ups.status")
+	return c.Text.Close()
+}
 
-	if err != nil {
-		return false, errors.New("Error getting current ups status")
+// Disconnect logs out from the server and closes the connection.
+// Further calls on the client return ErrConnectionClosed.
+func (c *Client) Disconnect() error {
+	if c.isclosed() {
+		return ErrConnectionClosed
 	}
 
-	if len(result) > 0 {
+	c.Logout()
+	return c.Close()
+}
+
+// SetContext bounds all further commands by ctx.
+// It does not reopen a connection closed after a command was interrupted by the
+// previous context, see WithContext.
+func (c *Client) SetContext(ctx context.Context) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.ctx = ctx
+}
 
-		if (strings.Contains(strings.ToUpper(result), "OL")) || (strings.Contains(strings.ToUpper(result), "BYPASS")) {
-			online = true
-		}
-	} else {
-		return false, errors.New("Cannot identify ups response")
+// Return the earliest of two deadlines, the zero time meaning no deadline
+func earliest(a time.Time, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
 	}
-	return online, nil
+	return a
 }
 
-// Return true if current ups is on battery
-func (c *Client) IsOnBattery() (bool, error) {
-	onbattery := false
-	if len([]rune(c.upsName)) == 0 {
-		return false, errors.New("No UPS defined, use LOGIN first")
+// Apply the cancellation of the client context to conn for the duration of
+// a command. The returned function must be called once the command is done.
+func (c *Client) watchcontext(conn net.Conn) (func(), error) {
+	c.mutex.Lock()
+	ctx := c.ctx
+	c.mutex.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	result, err := c.GetData("ups.status")
-	if err != nil {
-		return false, errors.New("Error getting current ups status")
+	stop := make(chan struct{})
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				// Unblock pending reads and writes
+				conn.SetDeadline(time.Unix(1, 0))
+			case <-stop:
+			}
+		}()
 	}
 
-	if len(result) > 0 {
-		if (strings.Contains(strings.ToUpper(result), "OB")) || (strings.Contains(strings.ToUpper(result), "LB")) {
-			onbattery = true
+	return func() {
+		close(stop)
+	}, nil
+}
+
+// Apply the write deadline set by the caller and the deadline of the client
+// context to conn while a command is sent. It must be called while owning the
+// request side of the pipeline ; the returned function restores the deadline
+// set by the caller.
+func (c *Client) writedeadline(conn net.Conn) func() {
+	c.mutex.Lock()
+	ctx := c.ctx
+	userdeadline := c.writeDeadline
+	c.mutex.Unlock()
+
+	deadline, _ := ctx.Deadline()
+	conn.SetWriteDeadline(earliest(userdeadline, deadline))
+	if ctx.Err() != nil {
+		conn.SetWriteDeadline(time.Unix(1, 0))
+	}
+
+	return func() {
+		if ctx.Err() == nil {
+			conn.SetWriteDeadline(userdeadline)
 		}
-	} else {
-		return false, errors.New("Cannot identify ups response")
 	}
-	return onbattery, nil
 }
 
-// Return true if current ups status is low battery
-func (c *Client) IsLowBattery() (bool, error) {
-	lowbattery := false
-	if len([]rune(c.upsName)) == 0 {
-		return false, errors.New("No UPS defined, use LOGIN first")
-	}
+// Apply the read deadline set by the caller, the read timeout and the deadline
+// of the client context to conn while a response is read. It must be called
+// while owning the response side of the pipeline, so that concurrent commands
+// do not reset the deadline of the one being answered ; the returned function
+// restores the deadline set by the caller.
+func (c *Client) readdeadline(conn net.Conn) func() {
+	c.mutex.Lock()
+	ctx := c.ctx
+	userdeadline := c.readDeadline
+	c.mutex.Unlock()
 
-	result, err := c.GetData("ups.status")
-	if err != nil {
-		return false, errors.New("Error getting current ups status")
+	deadline, _ := ctx.Deadline()
+	read := earliest(userdeadline, deadline)
+	if c.readTimeout > 0 {
+		read = earliest(read, time.Now().Add(c.readTimeout))
+	}
+	conn.SetReadDeadline(read)
+	if ctx.Err() != nil {
+		conn.SetReadDeadline(time.Unix(1, 0))
 	}
 
-	if len([]rune(result)) >= 2 {
-		if strings.ToUpper(result[0:2]) == "LB" {
-			lowbattery = true
+	return func() {
+		if ctx.Err() == nil {
+			conn.SetReadDeadline(userdeadline)
 		}
-	} else {
-		return false, errors.New("Cannot identify ups response")
 	}
-	return lowbattery, nil
 }
 
-// Return Battery Charge
-func (c *Client) BatteryCharge() (int, error) {
-	charge := -1
-	if len([]rune(c.upsName)) == 0 {
-		return charge, errors.New("No UPS defined, use LOGIN first")
-	}
+// SetDeadline sets the read and write deadlines of the connection.
+// After a timeout error the connection is closed, see WithReadTimeout.
+func (c *Client) SetDeadline(t time.Time) error {
+	c.mutex.Lock()
+	c.readDeadline = t
+	c.writeDeadline = t
+	conn := c.conn
+	c.mutex.Unlock()
 
-	result, err := c.GetData("battery.charge")
-	if err != nil {
-		return charge, errors.New("Error getting current battery charge")
+	return conn.SetDeadline(t)
+}
+
+// SetReadDeadline sets the read deadline of the connection.
+// After a timeout error the connection is closed, see WithReadTimeout.
+func (c *Client) SetReadDeadline(t time.Time) error {
+	c.mutex.Lock()
+	c.readDeadline = t
+	conn := c.conn
+	c.mutex.Unlock()
+
+	return conn.SetReadDeadline(t)
+}
+
+// SetWriteDeadline sets the write deadline of the connection.
+// After a timeout error the connection is closed, see WithReadTimeout.
+func (c *Client) SetWriteDeadline(t time.Time) error {
+	c.mutex.Lock()
+	c.writeDeadline = t
+	conn := c.conn
+	c.mutex.Unlock()
+
+	return conn.SetWriteDeadline(t)
+}
+
+// Report the context error for io errors caused by the context
+func (c *Client) contexterror(err error) error {
+	c.mutex.Lock()
+	ctx := c.ctx
+	c.mutex.Unlock()
+
+	if ctxerr := ctx.Err(); ctxerr != nil {
+		return ctxerr
+	}
+	// The connection deadline may expire just before the context does
+	if deadline, ok := ctx.Deadline(); ok && errors.Is(err, os.ErrDeadlineExceeded) && !time.Now().Before(deadline) {
+		return context.DeadlineExceeded
 	}
+	return err
+}
 
-	value, err := strconv.Atoi(result)
-	if err != nil {
-		return charge, errors.New("Cannot convert battery charge to numerical value")
-	} else {
-		charge = value
+// Return true once the connection has been closed
+func (c *Client) isclosed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.closed
+}
+
+// Report ErrConnectionClosed or the context error for io errors caused by a
+// closed connection or a done context
+func (c *Client) ioerror(err error) error {
+	if c.isclosed() {
+		return ErrConnectionClosed
 	}
-	return charge, nil
+	return c.contexterror(err)
 }
 
-// Return Battery Charge Low value
-func (c *Client) BatteryChargeLow() (int, error) {
-	charge := -1
-	if len([]rune(c.upsName)) == 0 {
-		return charge, errors.New("No UPS defined, use LOGIN first")
+// Close conn after an io error, such as a read timeout or a cancelled context,
+// left the protocol stream out of sync : commands queued behind the failed one
+// would otherwise read its late response. Returns the error to report.
+// Further calls return ErrConnectionClosed, unless the client reconnects.
+func (c *Client) abort(conn net.Conn, err error) error {
+	c.mutex.Lock()
+	current := c.conn == conn
+	c.mutex.Unlock()
+	if !current {
+		// The connection has already been replaced
+		return ErrConnectionClosed
 	}
 
-	result, err := c.GetData("battery.charge.low")
-	if err != nil {
-		return charge, errors.New("Error getting current battery charge low")
+	err = c.ioerror(err)
+
+	c.mutex.Lock()
+	if !c.closed {
+		c.closed = true
+		c.aborted = true
 	}
+	c.mutex.Unlock()
 
-	value, err := strconv.Atoi(result)
-	if err != nil {
-		return charge, errors.New("Cannot convert battery charge low to numerical value")
-	} else {
-		charge = value
+	conn.Close()
+	return err
+}
+
+// Restart the idle timer after a successful command
+func (c *Client) touch() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if (c.idleTimer != nil) && !c.closed {
+		c.idleTimer.Reset(c.idleTimeout)
 	}
-	return charge, nil
 }
 
-// Return Battery Charge Warning value
-func (c *Client) BatteryChargeWarning() (int, error) {
-	charge := -1
-	if len([]rune(c.upsName)) == 0 {
-		return charge, errors.New("No UPS defined, use LOGIN first")
+// Sends a command and reads its response, reconnecting after an io error
+// when an automatic reconnection policy is set
+func (c *Client) exchange(command string, multiline bool) (string, []string, error) {
+	response, retslice, err := c.transact(command, multiline)
+
+	if (err != nil) && c.mayreconnect(err) {
+		rerr := c.reconnect()
+		if rerr != nil {
+			c.log("error", "reconnection failed", map[string]interface{}{"server": c.serverName, "error": rerr.Error()})
+		}
 	}
+	return response, retslice, err
+}
 
-	result, err := c.GetData("battery.charge.warning")
-	if err != nil {
-		return charge, errors.New("Error getting current battery charge warning")
+// Sends a command and reads its response. Requests are sequenced through the
+// textproto pipeline so that commands issued from several goroutines are not
+// interleaved. If multiline is set and the server answers BEGIN, the lines up
+// to END are returned too.
+func (c *Client) transact(command string, multiline bool) (string, []string, error) {
+	var retslice []string
+
+	if c.isclosed() {
+		return "", nil, ErrConnectionClosed
 	}
 
-	value, err := strconv.Atoi(result)
+	c.mutex.Lock()
+	conn := c.conn
+	text := c.Text
+	c.mutex.Unlock()
+
+	release, err := c.watchcontext(conn)
 	if err != nil {
-		return charge, errors.New("Cannot convert battery charge warning to numerical value")
-	} else {
-		charge = value
+		return "", nil, err
 	}
-	return charge, nil
-}
+	defer release()
 
-// Return Battery Charge Restart value
-func (c *Client) BatteryChargeRestart() (int, error) {
-	charge := -1
-	if len([]rune(c.upsName)) == 0 {
-		return charge, errors.New("No UPS defined, use LOGIN first")
+	if c.logger != nil {
+		c.log("debug", "command sent", map[string]interface{}{"server": c.serverName, "command": redactcommand(command)})
 	}
 
-	result, err := c.GetData("battery.charge.restart")
+	id := text.Next()
+	text.StartRequest(id)
+	restore := c.writedeadline(conn)
+	err = text.PrintfLine("%s", command)
+	restore()
+	text.EndRequest(id)
+
+	text.StartResponse(id)
+	defer text.EndResponse(id)
+	defer c.readdeadline(conn)()
+
 	if err != nil {
-		return charge, errors.New("Error getting current battery charge restart")
+		return "", nil, c.abort(conn, err)
 	}
+	response, err := text.ReadLine()
 
-	value, err := strconv.Atoi(result)
 	if err != nil {
-		return charge, errors.New("Cannot convert battery charge restart to numerical value")
-	} else {
-		charge = value
+		return "", nil, c.abort(conn, err)
 	}
-	return charge, nil
-}
+	retcode, _, _ := strings.Cut(response, " ")
 
-// Return Battery runtime (seconds)
-func (c *Client) BatteryRuntime() (int, error) {
-	runtime := -1
-	if len([]rune(c.upsName)) == 0 {
-		return runtime, errors.New("No UPS defined, use LOGIN first")
-	}
+	if multiline && strings.EqualFold(retcode, "BEGIN") {
+		exitloop := false
+		for !exitloop {
+			line, err := text.ReadLine()
 
-	result, err := c.GetData("battery.runtime")
-	if err != nil {
-		return runtime, errors.New("Error getting current battery runtime")
+			if err != nil {
+				return "", nil, c.abort(conn, err)
+			}
+			retcode, _, _ := strings.Cut(line, " ")
+			if strings.EqualFold(retcode, "END") {
+				exitloop = true
+			} else {
+				retslice = append(retslice, line)
+			}
+		}
 	}
-
-	value, err := strconv.Atoi(result)
-	if err != nil {
-		return runtime, errors.New("Cannot convert battery runtime to numerical value")
-	} else {
-		runtime = value
+	if c.logger != nil {
+		fields := map[string]interface{}{"server": c.serverName, "response": response}
+		if multiline {
+			fields["lines"] = retslice
+		}
+		c.log("debug", "response received", fields)
 	}
-	return runtime, nil
+	c.touch()
+	return response, retslice, nil
 }
 
-// Return Battery runtime  low (seconds)
-func (c *Client) BatteryRuntimeLow() (int, error) {
-	runtime := -1
-	if len([]rune(c.upsName)) == 0 {
-		return runtime, errors.New("No UPS defined, use LOGIN first")
+// Return command with the password hidden, for logging
+func redactcommand(command string) string {
+	verb, _, _ := strings.Cut(command, " ")
+	if strings.EqualFold(verb, "PASSWORD") {
+		return verb + " ********"
 	}
+	return command
+}
 
-	result, err := c.GetData("battery.runtime.low")
-	if err != nil {
-		return runtime, errors.New("Error getting current battery runtime low")
+// Return true if err calls for a reconnection
+func (c *Client) mayreconnect(err error) bool {
+	if errors.Is(err, ErrConnectionClosed) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
 	}
 
-	value, err := strconv.Atoi(result)
-	if err != nil {
-		return runtime, errors.New("Cannot convert battery runtime low to numerical value")
-	} else {
-		runtime = value
-	}
-	return runtime, nil
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return (c.retryPolicy != nil) && (len(c.address) > 0) && !c.reconnecting
 }
 
-// Return Battery runtime restart (seconds)
-func (c *Client) BatteryRuntimeRestart() (int, error) {
-	runtime := -1
-	if len([]rune(c.upsName)) == 0 {
-		return runtime, errors.New("No UPS defined, use LOGIN first")
-	}
+// Re-dial the server and restore the session following the retry policy
+func (c *Client) reconnect() error {
+	c.mutex.Lock()
+	c.reconnecting = true
+	policy := *c.retryPolicy
+	ctx := c.ctx
+	c.mutex.Unlock()
 
-	result, err := c.GetData("battery.runtime.restart")
-	if err != nil {
-		return runtime, errors.New("Error getting current battery runtime restart")
-	}
+	defer func() {
+		c.mutex.Lock()
+		c.reconnecting = false
+		c.mutex.Unlock()
+	}()
 
-	value, err := strconv.Atoi(result)
-	if err != nil {
-		return runtime, errors.New("Cannot convert battery runtime restart to numerical value")
-	} else {
-		runtime = value
+	var err error
+	delay := policy.BaseDelay
+	for attempt := 0; attempt < policy.MaxAttempts || attempt == 0; attempt++ {
+		if attempt > 0 {
+			wait := delay
+			if policy.Jitter > 0 {
+				wait += time.Duration(rand.Int63n(int64(policy.Jitter)))
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(wait):
+			}
+			delay *= 2
+		}
+
+		c.log("info", "reconnecting", map[string]interface{}{"server": c.serverName, "attempt": attempt + 1})
+		err = c.redial()
+		if err == nil {
+			return nil
+		}
 	}
-	return runtime, nil
+	return err
 }
 
-// Return Server Info
-func (c *Client) GetServerInfo() (string, error) {
-	info := ""
+// Open a new connection and restore TLS, authentication and ups selection
+func (c *Client) redial() error {
+	var conn net.Conn
+	var err error
 
-	result, err := c.GetData("server.info")
+	if c.directTLS {
+		dialer := &tls.Dialer{NetDialer: &net.Dialer{Timeout: c.dialTimeout}, Config: c.tlsConfig}
+		conn, err = dialer.DialContext(c.ctx, "tcp", c.address)
+	} else {
+		dialer := net.Dialer{Timeout: c.dialTimeout}
+		conn, err = dialer.DialContext(c.ctx, "tcp", c.address)
+	}
 	if err != nil {
-		return info, errors.New("Error getting server.info")
+		return err
 	}
 
-	info = result
-	return info, nil
-}
+	c.mutex.Lock()
+	if c.closed && !c.aborted {
+		// Closed by the user while reconnecting
+		c.mutex.Unlock()
+		conn.Close()
+		return ErrConnectionClosed
+	}
+	c.closed = false
+	c.aborted = false
+	c.conn.Close()
+	c.conn = conn
+	c.Text = textproto.NewConn(conn)
+	c.tls = c.directTLS
+	c.mutex.Unlock()
 
-// Return Server Version
-func (c *Client) GetServerVersion() (string, error) {
-	info := ""
+	if c.startedTLS {
+		err = c.StartTLS(c.tlsConfig)
+		if err != nil {
+			return err
+		}
+	}
 
-	result, err := c.GetData("server.version")
-	if err != nil {
-		return info, errors.New("Error getting server.version")
+	if len(c.login) > 0 {
+		err = c.Auth(c.login, c.password)
+		if err != nil {
+			return err
+		}
 	}
 
-	info = result
-	return info, nil
+	if len([]rune(c.upsName)) > 0 {
+		err = c.Login(c.upsName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// Return configured UPS list
-func (c *Client) GetServerUpsList() ([]string, error) {
-	var retslice []string
+// Sends a command and returns the response
+func (c *Client) cmd(format string) (string, error) {
 
-	result, err := c.getmultilinesdata("LIST UPS")
+	response, _, err := c.exchange(format, false)
 
-	if (err != nil) || (len(result) == 0) {
-		return nil, errors.New("Error getting ups list")
+	if err != nil {
+		return "", err
 	}
+	retcode, retarg, _ := strings.Cut(response, " ")
 
-	for _, value := range result {
-		retcode, _, _ := strings.Cut(value, " ")
-
-		if strings.EqualFold(retcode, "UPS") {
-			argsstr := strings.Fields(value)
-			if len(argsstr) > 0 {
-				retslice = append(retslice, argsstr[1])
-			}
-		}
+	if strings.EqualFold(retcode, "OK") {
+		return retarg, nil
+	} else {
+		return "", responseerror(response)
 	}
-	return retslice, nil
 }
 
-// Return ups vars avaible
-func (c *Client) GetUpsVars() ([]string, error) {
-	var retslice []string
+// A NutError is an ERR response returned by the server.
+// It matches the sentinel error of its code with errors.Is.
+type NutError struct {
+	Code    string // ERR code, such as ACCESS-DENIED
+	Message string // full response line
+}
 
-	if len([]rune(c.upsName)) == 0 {
-		return nil, errors.New("No UPS defined, use LOGIN first")
-	}
+// Error returns the response line sent by the server
+func (e *NutError) Error() string {
+	return e.Message
+}
 
-	result, err := c.getmultilinesdata("LIST VAR " + c.upsName)
+// Unwrap returns the sentinel error matching the ERR code, if any
+func (e *NutError) Unwrap() error {
+	return responseErrors[e.Code]
+}
 
-	if (err != nil) || (len(result) == 0) {
-		return nil, errors.New("Error getting ups list")
+// Return the error matching an unexpected response line.
+// ERR responses are returned as a *NutError.
+func responseerror(response string) error {
+	fields := strings.Fields(response)
+	if len(fields) >= 2 && strings.EqualFold(fields[0], "ERR") {
+		return &NutError{Code: strings.ToUpper(fields[1]), Message: response}
 	}
+	return errors.New(response)
+}
 
-	for _, value := range result {
-		retcode, _, _ := strings.Cut(value, " ")
+// Get a specific data from current ups
+func (c *Client) GetData(format string) (string, error) {
 
-		if strings.EqualFold(retcode, "VAR") {
-			argsstr := strings.Fields(value)
-			if len(argsstr) > 3 {
-				retslice = append(retslice, argsstr[2])
-			}
-		}
+	if len([]rune(c.upsName)) == 0 {
+		return "", errors.New("No UPS defined, use LOGIN first")
 	}
-	return retslice, nil
-}
 
-// Return ups load (percent)
-func (c *Client) UpsLoad() (int, error) {
-	upsload := -1
-	if len([]rune(c.upsName)) == 0 {
-		return upsload, errors.New("No UPS defined, use LOGIN first")
+	if len(format) == 0 {
+		return "", errors.New("Variable cannot be empty")
 	}
 
-	result, err := c.GetData("ups.load")
+	command := "GET VAR " + c.upsName + " " + format
+
+	response, _, err := c.exchange(command, false)
+
 	if err != nil {
-		return upsload, errors.New("Error getting current ups load")
+		return "", err
 	}
+	retcode, _, _ := strings.Cut(response, " ")
 
-	value, err := strconv.Atoi(result)
-	if err != nil {
-		return upsload, errors.New("Cannot convert ups load to numerical value")
+	if strings.EqualFold(retcode, "VAR") {
+		argsstr := strings.Fields(response)
+		if (len(argsstr) < 3) || (argsstr[1] != c.upsName) || (argsstr[2] != format) {
+			return "", errors.New("Unexpected response " + response)
+		}
+		return quotedvalue(response), nil
 	} else {
-		upsload = value
+		return "", responseerror(response)
 	}
-	return upsload, nil
 }
 
-// Return ups load (degrees C)
-func (c *Client) UpsTemperature() (int, error) {
-	upstemperature := -1
-	if len([]rune(c.upsName)) == 0 {
-		return upstemperature, errors.New("No UPS defined, use LOGIN first")
-	}
+// Sends a command answered by a single free-form line and returns that line
+func (c *Client) rawcmd(command string) (string, error) {
+
+	response, _, err := c.exchange(command, false)
 
-	result, err := c.GetData("ups.temperature")
 	if err != nil {
-		return upstemperature, errors.New("Error getting current ups temperature")
+		return "", err
 	}
+	retcode, _, _ := strings.Cut(response, " ")
 
-	value, err := strconv.Atoi(result)
-	if err != nil {
-		return upstemperature, errors.New("Cannot convert ups temperature to numerical value")
-	} else {
-		upstemperature = value
+	if strings.EqualFold(retcode, "ERR") {
+		return "", responseerror(response)
 	}
-	return upstemperature, nil
+	return response, nil
 }
 
-// Return current apparent ups power (VA)
-func (c *Client) UpsApparentPower() (int, error) {
-	upspower := -1
-	if len([]rune(c.upsName)) == 0 {
-		return upspower, errors.New("No UPS defined, use LOGIN first")
-	}
+// Send a GET query and return the response line if it is of the expected kind
+func (c *Client) getresponse(query string, expected string) (string, error) {
+
+	response, _, err := c.exchange("GET "+query, false)
 
-	result, err := c.GetData("ups.power")
 	if err != nil {
-		return upspower, errors.New("Error getting current ups apparent power")
+		return "", err
 	}
+	retcode, _, _ := strings.Cut(response, " ")
 
-	value, err := strconv.Atoi(result)
-	if err != nil {
-		return upspower, errors.New("Cannot convert ups apparent power to numerical value")
+	if strings.EqualFold(retcode, expected) {
+		return response, nil
 	} else {
-		upspower = value
+		return "", responseerror(response)
 	}
-	return upspower, nil
 }
 
-// Return current active ups power (W)
-func (c *Client) UpsActivePower() (int, error) {
-	upspower := -1
-	if len([]rune(c.upsName)) == 0 {
-		return upspower, errors.New("No UPS defined, use LOGIN first")
-	}
+// Get a multiline data response
+func (c *Client) getmultilinesdata(command string) ([]string, error) {
 
-	result, err := c.GetData("ups.realpower")
-	if err != nil {
-		return upspower, errors.New("Error getting current ups active power")
+	if len(command) == 0 {
+		return nil, errors.New("Variable cannot be empty")
 	}
 
-	value, err := strconv.Atoi(result)
+	response, retslice, err := c.exchange(command, true)
+
 	if err != nil {
-		return upspower, errors.New("Cannot convert ups active power to numerical value")
-	} else {
-		upspower = value
+		return nil, err
 	}
-	return upspower, nil
-}
+	retcode, _, _ := strings.Cut(response, " ")
 
-// Return Input Voltage (V)
-func (c *Client) InputVoltage() (int, error) {
-	voltage := -1
-	if len([]rune(c.upsName)) == 0 {
-		return voltage, errors.New("No UPS defined, use LOGIN first")
+	if !strings.EqualFold(retcode, "BEGIN") {
+		return nil, responseerror(response)
 	}
+	return retslice, nil
+}
 
-	result, err := c.GetData("input.voltage")
+// Get a specific data from current ups as a float value
+func (c *Client) GetDataFloat(variable string) (float64, error) {
+	result, err := c.GetData(variable)
 	if err != nil {
-		return voltage, errors.New("Error getting current input voltage")
+		return -1, err
 	}
 
-	value, err := strconv.Atoi(result)
+	value, err := strconv.ParseFloat(strings.TrimSpace(result), 64)
 	if err != nil {
-		return voltage, errors.New("Cannot convert input voltage to numerical value")
-	} else {
-		voltage = value
+		return -1, fmt.Errorf("Cannot convert %s to numerical value: %w", variable, err)
 	}
-	return voltage, nil
+	return value, nil
 }
 
-// Return Input Current (A)
-func (c *Client) InputCurrent() (int, error) {
-	courant := -1
+// Get a specific data from current ups and convert it to a float value.
+// name is used to build error messages ; ErrUnknownVariable is returned as is.
+func (c *Client) getfloatdata(variable string, name string) (float64, error) {
 	if len([]rune(c.upsName)) == 0 {
-		return courant, errors.New("No UPS defined, use LOGIN first")
+		return -1, errors.New("No UPS defined, use LOGIN first")
 	}
 
-	result, err := c.GetData("input.current")
-	if err != nil {
-		return courant, errors.New("Error getting current input current")
+	result, err := c.GetData(variable)
+	if errors.Is(err, ErrUnknownVariable) {
+		return -1, err
+	} else if err != nil {
+		return -1, fmt.Errorf("Error getting current %s: %w", name, err)
 	}
 
-	value, err := strconv.Atoi(result)
+	value, err := strconv.ParseFloat(result, 64)
 	if err != nil {
-		return courant, errors.New("Cannot convert input current to numerical value")
-	} else {
-		courant = value
+		return -1, fmt.Errorf("Cannot convert %s to numerical value: %w", name, err)
 	}
-	return courant, nil
+	return value, nil
 }
 
-// Return Output Voltage (V)
-func (c *Client) OutputVoltage() (int, error) {
-	voltage := -1
+// Get a specific string data from current ups. name is used to build error
+// messages ; ErrUnknownVariable is returned as is.
+func (c *Client) getstringdata(variable string, name string) (string, error) {
 	if len([]rune(c.upsName)) == 0 {
-		return voltage, errors.New("No UPS defined, use LOGIN first")
+		return "", errors.New("No UPS defined, use LOGIN first")
 	}
 
-	result, err := c.GetData("output.voltage")
-	if err != nil {
-		return voltage, errors.New("Error getting current output voltage")
+	result, err := c.GetData(variable)
+	if errors.Is(err, ErrUnknownVariable) {
+		return "", err
+	} else if err != nil {
+		return "", fmt.Errorf("Error getting current %s: %w", name, err)
 	}
+	return result, nil
+}
 
-	value, err := strconv.Atoi(result)
+// Get a specific data from current ups and convert it to an integer value.
+// name is used to build error messages ; ErrUnknownVariable is returned as is.
+func (c *Client) getintdata(variable string, name string) (int, error) {
+	result, err := c.getstringdata(variable, name)
 	if err != nil {
-		return voltage, errors.New("Cannot convert output voltage to numerical value")
-	} else {
-		voltage = value
+		return -1, err
 	}
-	return voltage, nil
-}
 
-// Return Output Current (A)
-func (c *Client) OutputCurrent() (int, error) {
-	courant := -1
-	if len([]rune(c.upsName)) == 0 {
-		return courant, errors.New("No UPS defined, use LOGIN first")
+	value, err := strconv.Atoi(strings.TrimSpace(result))
+	if err != nil {
+		return -1, fmt.Errorf("Cannot convert %s to numerical value: %w", name, err)
 	}
+	return value, nil
+}
 
-	result, err := c.GetData("output.current")
+// Get a specific yes/no data from current ups and convert it to a boolean value.
+// name is used to build error messages ; ErrUnknownVariable is returned as is.
+func (c *Client) getbooldata(variable string, name string) (bool, error) {
+	result, err := c.getstringdata(variable, name)
 	if err != nil {
-		return courant, errors.New("Error getting current output current")
+		return false, err
 	}
 
-	value, err := strconv.Atoi(result)
-	if err != nil {
-		return courant, errors.New("Cannot convert output current to numerical value")
-	} else {
-		courant = value
+	switch strings.ToLower(strings.TrimSpace(result)) {
+	case "yes", "on", "enabled", "1":
+		return true, nil
+	case "no", "off", "disabled", "0":
+		return false, nil
 	}
-	return courant, nil
+	return false, errors.New("Cannot convert " + name + " to boolean value")
 }
 
-// Return Output Frequency (Hz)
-func (c *Client) OutputFrequency() (int, error) {
-	frequency := -1
+// Set a variable of current ups
+func (c *Client) setvar(variable string, value string) error {
 	if len([]rune(c.upsName)) == 0 {
-		return frequency, errors.New("No UPS defined, use LOGIN first")
+		return errors.New("No UPS defined, use LOGIN first")
 	}
 
-	result, err := c.GetData("output.frequency")
-	if err != nil {
-		return frequency, errors.New("Error getting current output frequency")
+	if len(variable) == 0 {
+		return errors.New("Variable cannot be empty")
 	}
 
-	value, err := strconv.Atoi(result)
-	if err != nil {
-		return frequency, errors.New("Cannot convert output frequency to numerical value")
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "\"", "\\\"")
+	_, err := c.cmd("SET VAR " + c.upsName + " " + variable + " \"" + value + "\"")
+	return err
+}
+
+// Extract the quoted value from a nut response line
+func quotedvalue(response string) string {
+	_, value, _ := strings.Cut(response, "\"")
+	value = strings.TrimSuffix(value, "\"")
+	value = strings.ReplaceAll(value, "\\\"", "\"")
+	return strings.ReplaceAll(value, "\\\\", "\\")
+}
+
+// StartTLS sends the STARTTLS command and encrypts all further communication.
+// When configtls is nil, the configuration set by WithTLSConfig is used.
+func (c *Client) StartTLS(configtls *tls.Config) error {
+
+	if configtls == nil {
+		configtls = c.tlsConfig
+	}
+	if configtls == nil {
+		configtls = &tls.Config{ServerName: c.serverName}
+	}
+
+	_, err := c.cmd("STARTTLS")
+	if err != nil {
+		return err
+	}
+	c.conn = tls.Client(c.conn, configtls)
+	c.Text = textproto.NewConn(c.conn)
+	c.tls = true
+	c.tlsConfig = configtls
+	c.startedTLS = true
+	c.log("info", "tls started", map[string]interface{}{"server": c.serverName})
+	return err
+}
+
+// Perform Auth on nut server
+func (c *Client) Auth(login string, password string) error {
+
+	_, err := c.cmd("USERNAME " + login)
+	if err != nil {
+		return fmt.Errorf("ERROR : Bad Login %w", err)
+	}
+
+	_, err = c.cmd("PASSWORD " + password)
+	if err != nil {
+		return fmt.Errorf("ERROR : Bad Password %w", err)
+	}
+
+	if c.retryPolicy != nil {
+		// Kept to authenticate again after a reconnection
+		c.login = login
+		c.password = password
+	}
+	return nil
+}
+
+// Perform Login command to select current ups
+func (c *Client) Login(upsName string) error {
+
+	_, err := c.cmd("LOGIN " + upsName)
+	if err != nil {
+		return err
+	}
+
+	c.upsName = upsName
+	return nil
+}
+
+// Perform Logout command.
+func (c *Client) Logout() error {
+
+	_, err := c.cmd("LOGOUT")
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Return current ups status, with all ups.status flags parsed in one round-trip
+func (c *Client) GetUpsStatus() (UpsStatus, error) {
+	if len([]rune(c.upsName)) == 0 {
+		return UpsStatus{}, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("ups.status")
+	if err != nil {
+		return UpsStatus{}, fmt.Errorf("Error getting current ups status: %w", err)
+	}
+
+	if len(strings.TrimSpace(result)) == 0 {
+		return UpsStatus{}, errors.New("Cannot identify ups response")
+	}
+	return *ParseUpsStatus(result), nil
+}
+
+// Return true if current ups is online
+func (c *Client) IsOnline() (bool, error) {
+	status, err := c.GetUpsStatus()
+	if err != nil {
+		return false, err
+	}
+	return status.Online || status.Bypass, nil
+}
+
+// Return true if current ups is on battery
+func (c *Client) IsOnBattery() (bool, error) {
+	status, err := c.GetUpsStatus()
+	if err != nil {
+		return false, err
+	}
+	return status.OnBattery || status.LowBattery, nil
+}
+
+// Return true if current ups status is low battery
+func (c *Client) IsLowBattery() (bool, error) {
+	status, err := c.GetUpsStatus()
+	if err != nil {
+		return false, err
+	}
+	return status.LowBattery, nil
+}
+
+// Return Battery Charge
+func (c *Client) BatteryCharge() (int, error) {
+	charge := -1
+	if len([]rune(c.upsName)) == 0 {
+		return charge, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("battery.charge")
+	if err != nil {
+		return charge, fmt.Errorf("Error getting current battery charge: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return charge, fmt.Errorf("Cannot convert battery charge to numerical value: %w", err)
 	} else {
-		frequency = value
+		charge = value
 	}
-	return frequency, nil
+	return charge, nil
 }
 
-// Return Input Frequency (Hz)
-func (c *Client) InputFrequency() (int, error) {
-	frequency := -1
+// Return Battery Charge Low value
+func (c *Client) BatteryChargeLow() (int, error) {
+	charge := -1
 	if len([]rune(c.upsName)) == 0 {
-		return frequency, errors.New("No UPS defined, use LOGIN first")
+		return charge, errors.New("No UPS defined, use LOGIN first")
 	}
 
-	result, err := c.GetData("input.frequency")
+	result, err := c.GetData("battery.charge.low")
 	if err != nil {
-		return frequency, errors.New("Error getting current input frequency")
+		return charge, fmt.Errorf("Error getting current battery charge low: %w", err)
 	}
 
 	value, err := strconv.Atoi(result)
 	if err != nil {
-		return frequency, errors.New("Cannot convert input frequency to numerical value")
+		return charge, fmt.Errorf("Cannot convert battery charge low to numerical value: %w", err)
 	} else {
-		frequency = value
+		charge = value
 	}
-	return frequency, nil
+	return charge, nil
 }
 
-// Return Ups Model
-func (c *Client) GetUpsModel() (string, error) {
-	info := ""
+// Return Battery Charge Warning value
+func (c *Client) BatteryChargeWarning() (int, error) {
+	charge := -1
 	if len([]rune(c.upsName)) == 0 {
-		return info, errors.New("No UPS defined, use LOGIN first")
+		return charge, errors.New("No UPS defined, use LOGIN first")
 	}
 
-	info, err := c.GetData("ups.model")
+	result, err := c.GetData("battery.charge.warning")
+	if err != nil {
+		return charge, fmt.Errorf("Error getting current battery charge warning: %w", err)
+	}
 
+	value, err := strconv.Atoi(result)
 	if err != nil {
-		return info, errors.New("Error getting server.version")
+		return charge, fmt.Errorf("Cannot convert battery charge warning to numerical value: %w", err)
+	} else {
+		charge = value
 	}
+	return charge, nil
+}
 
-	return info, nil
+// Return Battery Charge Restart value
+func (c *Client) BatteryChargeRestart() (int, error) {
+	charge := -1
+	if len([]rune(c.upsName)) == 0 {
+		return charge, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("battery.charge.restart")
+	if err != nil {
+		return charge, fmt.Errorf("Error getting current battery charge restart: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return charge, fmt.Errorf("Cannot convert battery charge restart to numerical value: %w", err)
+	} else {
+		charge = value
+	}
+	return charge, nil
 }
 
-// Return Ups Serial Number
-func (c *Client) GetUpsSerial() (string, error) {
-	info := ""
+// Return Battery runtime (seconds)
+func (c *Client) BatteryRuntime() (int, error) {
+	runtime := -1
 	if len([]rune(c.upsName)) == 0 {
-		return info, errors.New("No UPS defined, use LOGIN first")
+		return runtime, errors.New("No UPS defined, use LOGIN first")
 	}
 
-	info, err := c.GetData("ups.serial")
+	result, err := c.GetData("battery.runtime")
+	if err != nil {
+		return runtime, fmt.Errorf("Error getting current battery runtime: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return runtime, fmt.Errorf("Cannot convert battery runtime to numerical value: %w", err)
+	} else {
+		runtime = value
+	}
+	return runtime, nil
+}
+
+// Return Battery runtime  low (seconds)
+func (c *Client) BatteryRuntimeLow() (int, error) {
+	runtime := -1
+	if len([]rune(c.upsName)) == 0 {
+		return runtime, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("battery.runtime.low")
+	if err != nil {
+		return runtime, fmt.Errorf("Error getting current battery runtime low: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return runtime, fmt.Errorf("Cannot convert battery runtime low to numerical value: %w", err)
+	} else {
+		runtime = value
+	}
+	return runtime, nil
+}
+
+// Return Battery runtime restart (seconds)
+func (c *Client) BatteryRuntimeRestart() (int, error) {
+	runtime := -1
+	if len([]rune(c.upsName)) == 0 {
+		return runtime, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("battery.runtime.restart")
+	if err != nil {
+		return runtime, fmt.Errorf("Error getting current battery runtime restart: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return runtime, fmt.Errorf("Cannot convert battery runtime restart to numerical value: %w", err)
+	} else {
+		runtime = value
+	}
+	return runtime, nil
+}
+
+// Return Server Info
+func (c *Client) GetServerInfo() (string, error) {
+	info := ""
+
+	result, err := c.GetData("server.info")
+	if err != nil {
+		return info, fmt.Errorf("Error getting server.info: %w", err)
+	}
+
+	info = result
+	return info, nil
+}
+
+// Return Server Version
+func (c *Client) GetServerVersion() (string, error) {
+	info := ""
 
+	result, err := c.GetData("server.version")
 	if err != nil {
-		return info, errors.New("Error getting server.version")
+		return info, fmt.Errorf("Error getting server.version: %w", err)
 	}
 
+	info = result
 	return info, nil
 }
+
+// Return configured UPS list
+func (c *Client) GetServerUpsList() ([]string, error) {
+	var retslice []string
+
+	result, err := c.getmultilinesdata("LIST UPS")
+
+	if err != nil {
+		return nil, fmt.Errorf("Error getting ups list: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("Error getting ups list")
+	}
+
+	for _, value := range result {
+		retcode, _, _ := strings.Cut(value, " ")
+
+		if strings.EqualFold(retcode, "UPS") {
+			argsstr := strings.Fields(value)
+			if len(argsstr) > 0 {
+				retslice = append(retslice, argsstr[1])
+			}
+		}
+	}
+	return retslice, nil
+}
+
+// Return ups vars avaible
+func (c *Client) GetUpsVars() ([]string, error) {
+	var retslice []string
+
+	if len([]rune(c.upsName)) == 0 {
+		return nil, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.getmultilinesdata("LIST VAR " + c.upsName)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error getting ups list: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("Error getting ups list")
+	}
+
+	for _, value := range result {
+		retcode, _, _ := strings.Cut(value, " ")
+
+		if strings.EqualFold(retcode, "VAR") {
+			argsstr := strings.Fields(value)
+			if len(argsstr) > 3 {
+				retslice = append(retslice, argsstr[2])
+			}
+		}
+	}
+	return retslice, nil
+}
+
+// Return ups load (percent)
+// Decimal values such as "23.0" cannot be converted, use UpsLoadFloat for those.
+func (c *Client) UpsLoad() (int, error) {
+	upsload := -1
+	if len([]rune(c.upsName)) == 0 {
+		return upsload, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("ups.load")
+	if err != nil {
+		return upsload, fmt.Errorf("Error getting current ups load: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return upsload, fmt.Errorf("Cannot convert ups load to numerical value: %w", err)
+	} else {
+		upsload = value
+	}
+	return upsload, nil
+}
+
+// Return ups temperature (degrees C)
+// Decimal values such as "35.2" cannot be converted, use UpsTemperatureFloat for those.
+func (c *Client) UpsTemperature() (int, error) {
+	upstemperature := -1
+	if len([]rune(c.upsName)) == 0 {
+		return upstemperature, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("ups.temperature")
+	if err != nil {
+		return upstemperature, fmt.Errorf("Error getting current ups temperature: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return upstemperature, fmt.Errorf("Cannot convert ups temperature to numerical value: %w", err)
+	} else {
+		upstemperature = value
+	}
+	return upstemperature, nil
+}
+
+// Return current apparent ups power (VA)
+// Decimal values such as "450.5" cannot be converted, use UpsApparentPowerFloat for those.
+func (c *Client) UpsApparentPower() (int, error) {
+	upspower := -1
+	if len([]rune(c.upsName)) == 0 {
+		return upspower, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("ups.power")
+	if err != nil {
+		return upspower, fmt.Errorf("Error getting current ups apparent power: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return upspower, fmt.Errorf("Cannot convert ups apparent power to numerical value: %w", err)
+	} else {
+		upspower = value
+	}
+	return upspower, nil
+}
+
+// Return current active ups power (W)
+// Decimal values such as "410.5" cannot be converted, use UpsActivePowerFloat for those.
+func (c *Client) UpsActivePower() (int, error) {
+	upspower := -1
+	if len([]rune(c.upsName)) == 0 {
+		return upspower, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("ups.realpower")
+	if err != nil {
+		return upspower, fmt.Errorf("Error getting current ups active power: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return upspower, fmt.Errorf("Cannot convert ups active power to numerical value: %w", err)
+	} else {
+		upspower = value
+	}
+	return upspower, nil
+}
+
+// Return Input Voltage (V)
+// Decimal values such as "230.4" cannot be converted, use InputVoltageFloat for sub-volt precision.
+func (c *Client) InputVoltage() (int, error) {
+	voltage := -1
+	if len([]rune(c.upsName)) == 0 {
+		return voltage, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("input.voltage")
+	if err != nil {
+		return voltage, fmt.Errorf("Error getting current input voltage: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return voltage, fmt.Errorf("Cannot convert input voltage to numerical value: %w", err)
+	} else {
+		voltage = value
+	}
+	return voltage, nil
+}
+
+// Return Input Current (A)
+//
+// Deprecated: the value is truncated to an integer, use InputCurrentMeasured instead.
+func (c *Client) InputCurrent() (int, error) {
+	courant := -1
+	if len([]rune(c.upsName)) == 0 {
+		return courant, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("input.current")
+	if err != nil {
+		return courant, fmt.Errorf("Error getting current input current: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return courant, fmt.Errorf("Cannot convert input current to numerical value: %w", err)
+	} else {
+		courant = value
+	}
+	return courant, nil
+}
+
+// Return Output Voltage (V)
+// Decimal values such as "230.4" cannot be converted, use OutputVoltageFloat for sub-volt precision.
+func (c *Client) OutputVoltage() (int, error) {
+	voltage := -1
+	if len([]rune(c.upsName)) == 0 {
+		return voltage, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("output.voltage")
+	if err != nil {
+		return voltage, fmt.Errorf("Error getting current output voltage: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return voltage, fmt.Errorf("Cannot convert output voltage to numerical value: %w", err)
+	} else {
+		voltage = value
+	}
+	return voltage, nil
+}
+
+// Return Output Current (A)
+// Decimal values cannot be converted, use OutputCurrentFloat for fractional amperes.
+func (c *Client) OutputCurrent() (int, error) {
+	courant := -1
+	if len([]rune(c.upsName)) == 0 {
+		return courant, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("output.current")
+	if err != nil {
+		return courant, fmt.Errorf("Error getting current output current: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return courant, fmt.Errorf("Cannot convert output current to numerical value: %w", err)
+	} else {
+		courant = value
+	}
+	return courant, nil
+}
+
+// Return Output Frequency (Hz)
+// Decimal values such as "49.8" cannot be converted, use OutputFrequencyFloat instead.
+func (c *Client) OutputFrequency() (int, error) {
+	frequency := -1
+	if len([]rune(c.upsName)) == 0 {
+		return frequency, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("output.frequency")
+	if err != nil {
+		return frequency, fmt.Errorf("Error getting current output frequency: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return frequency, fmt.Errorf("Cannot convert output frequency to numerical value: %w", err)
+	} else {
+		frequency = value
+	}
+	return frequency, nil
+}
+
+// Return Input Frequency (Hz)
+// Decimal values such as "49.8" cannot be converted, use InputFrequencyFloat instead.
+func (c *Client) InputFrequency() (int, error) {
+	frequency := -1
+	if len([]rune(c.upsName)) == 0 {
+		return frequency, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.GetData("input.frequency")
+	if err != nil {
+		return frequency, fmt.Errorf("Error getting current input frequency: %w", err)
+	}
+
+	value, err := strconv.Atoi(result)
+	if err != nil {
+		return frequency, fmt.Errorf("Cannot convert input frequency to numerical value: %w", err)
+	} else {
+		frequency = value
+	}
+	return frequency, nil
+}
+
+// Return Ups Model
+func (c *Client) GetUpsModel() (string, error) {
+	info := ""
+	if len([]rune(c.upsName)) == 0 {
+		return info, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	info, err := c.GetData("ups.model")
+
+	if err != nil {
+		return info, fmt.Errorf("Error getting ups.model: %w", err)
+	}
+
+	return info, nil
+}
+
+// Return Ups Serial Number
+func (c *Client) GetUpsSerial() (string, error) {
+	info := ""
+	if len([]rune(c.upsName)) == 0 {
+		return info, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	info, err := c.GetData("ups.serial")
+
+	if err != nil {
+		return info, fmt.Errorf("Error getting ups.serial: %w", err)
+	}
+
+	return info, nil
+}
+
+// An UpsEntry represents an ups declared on the nut server
+type UpsEntry struct {
+	Name        string
+	Description string
+}
+
+// An UpsStatus holds the flags reported in ups.status
+type UpsStatus struct {
+	Raw            string
+	Online         bool // OL
+	OnBattery      bool // OB
+	LowBattery     bool // LB
+	HighBattery    bool // HB
+	ReplaceBattery bool // RB
+	Charging       bool // CHRG
+	Discharging    bool // DISCHRG
+	Bypass         bool // BYPASS
+	Calibrating    bool // CAL
+	Off            bool // OFF
+	Overloaded     bool // OVER
+	Trim           bool // TRIM
+	Boost          bool // BOOST
+	ForcedShutdown bool // FSD
+	// Err is set when the status of this ups could not be retrieved
+	Err error
+}
+
+// ParseUpsStatus parses a raw ups.status value such as "OL CHRG"
+func ParseUpsStatus(status string) *UpsStatus {
+	s := &UpsStatus{Raw: status}
+
+	for _, token := range strings.Fields(strings.ToUpper(status)) {
+		switch token {
+		case "OL":
+			s.Online = true
+		case "OB":
+			s.OnBattery = true
+		case "LB":
+			s.LowBattery = true
+		case "HB":
+			s.HighBattery = true
+		case "RB":
+			s.ReplaceBattery = true
+		case "CHRG":
+			s.Charging = true
+		case "DISCHRG":
+			s.Discharging = true
+		case "BYPASS":
+			s.Bypass = true
+		case "CAL":
+			s.Calibrating = true
+		case "OFF":
+			s.Off = true
+		case "OVER":
+			s.Overloaded = true
+		case "TRIM":
+			s.Trim = true
+		case "BOOST":
+			s.Boost = true
+		case "FSD":
+			s.ForcedShutdown = true
+		}
+	}
+	return s
+}
+
+// Return configured UPS list with their description
+func (c *Client) GetServerUpsEntries() ([]UpsEntry, error) {
+	var retslice []UpsEntry
+
+	result, err := c.getmultilinesdata("LIST UPS")
+
+	if err != nil {
+		return nil, fmt.Errorf("Error getting ups list: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("Error getting ups list")
+	}
+
+	for _, value := range result {
+		retcode, _, _ := strings.Cut(value, " ")
+
+		if strings.EqualFold(retcode, "UPS") {
+			argsstr := strings.Fields(value)
+			if len(argsstr) > 1 {
+				retslice = append(retslice, UpsEntry{Name: argsstr[1], Description: quotedvalue(value)})
+			}
+		}
+	}
+	return retslice, nil
+}
+
+// Return all vars and their values for the given ups
+func (c *Client) GetAllVarsForUps(upsName string) (map[string]string, error) {
+	retmap := make(map[string]string)
+
+	if len([]rune(upsName)) == 0 {
+		return nil, errors.New("UPS name cannot be empty")
+	}
+
+	result, err := c.getmultilinesdata("LIST VAR " + upsName)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error getting ups vars: %w", err)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("Error getting ups vars")
+	}
+
+	for _, value := range result {
+		retcode, _, _ := strings.Cut(value, " ")
+
+		if strings.EqualFold(retcode, "VAR") {
+			argsstr := strings.Fields(value)
+			if len(argsstr) > 3 {
+				retmap[argsstr[2]] = quotedvalue(value)
+			}
+		}
+	}
+	return retmap, nil
+}
+
+// Return all vars and their values for current ups
+func (c *Client) GetAllVars() (map[string]string, error) {
+	if len([]rune(c.upsName)) == 0 {
+		return nil, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	return c.GetAllVarsForUps(c.upsName)
+}
+
+// Return the status of every ups configured on the server.
+// Ups are queried concurrently ; a failure on one ups is reported in its Err field.
+func (c *Client) GetServerUpsStatusSummary() (map[string]*UpsStatus, error) {
+	entries, err := c.GetServerUpsEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	retmap := make(map[string]*UpsStatus)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, entry := range entries {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+
+			var status *UpsStatus
+			vars, err := c.GetAllVarsForUps(name)
+			if err != nil {
+				status = &UpsStatus{Err: err}
+			} else if raw, ok := vars["ups.status"]; ok {
+				status = ParseUpsStatus(raw)
+			} else {
+				status = &UpsStatus{Err: errors.New("Error getting current ups status")}
+			}
+
+			mu.Lock()
+			retmap[name] = status
+			mu.Unlock()
+		}(entry.Name)
+	}
+	wg.Wait()
+
+	return retmap, nil
+}
+
+// A PowerQualityReport gathers input and output power quality measurements.
+// Measurements not reported by the driver are left nil.
+type PowerQualityReport struct {
+	Timestamp         time.Time
+	DriverName        string
+	InputVoltage      *float64
+	InputCurrent      *float64
+	InputFrequency    *float64
+	InputPowerFactor  *float64
+	InputVoltageTHD   *float64
+	InputCurrentTHD   *float64
+	OutputVoltage     *float64
+	OutputCurrent     *float64
+	OutputFrequency   *float64
+	OutputPowerFactor *float64
+	OutputVoltageTHD  *float64
+	OutputCurrentTHD  *float64
+}
+
+// Return a pointer to the numerical value of a var, or nil if absent or not numerical
+func floatvar(vars map[string]string, name string) *float64 {
+	result, ok := vars[name]
+	if !ok {
+		return nil
+	}
+
+	value, err := strconv.ParseFloat(result, 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// Return power quality measurements of current ups, fetched in a single call
+func (c *Client) GetPowerQualityReport() (*PowerQualityReport, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PowerQualityReport{
+		Timestamp:         time.Now(),
+		DriverName:        vars["driver.name"],
+		InputVoltage:      floatvar(vars, "input.voltage"),
+		InputCurrent:      floatvar(vars, "input.current"),
+		InputFrequency:    floatvar(vars, "input.frequency"),
+		InputPowerFactor:  floatvar(vars, "input.powerfactor"),
+		InputVoltageTHD:   floatvar(vars, "input.voltage.THD"),
+		InputCurrentTHD:   floatvar(vars, "input.current.THD"),
+		OutputVoltage:     floatvar(vars, "output.voltage"),
+		OutputCurrent:     floatvar(vars, "output.current"),
+		OutputFrequency:   floatvar(vars, "output.frequency"),
+		OutputPowerFactor: floatvar(vars, "output.powerfactor"),
+		OutputVoltageTHD:  floatvar(vars, "output.voltage.THD"),
+		OutputCurrentTHD:  floatvar(vars, "output.current.THD"),
+	}
+	return report, nil
+}
+
+// A CurrentDirection tells whether the battery is charging or discharging
+type CurrentDirection int
+
+const (
+	CurrentIdle CurrentDirection = iota
+	CurrentCharging
+	CurrentDischarging
+)
+
+// Return battery current direction and magnitude (A).
+// battery.current is used first (positive when charging), then
+// battery.current.charge and battery.current.discharge. As a last resort the
+// direction is inferred from ups.status CHRG/DISCHRG flags, with a zero magnitude.
+func (c *Client) BatteryCurrentDirection() (direction CurrentDirection, magnitude float64, err error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return CurrentIdle, 0, err
+	}
+
+	if current := floatvar(vars, "battery.current"); current != nil {
+		switch {
+		case *current > 0:
+			return CurrentCharging, *current, nil
+		case *current < 0:
+			return CurrentDischarging, -*current, nil
+		default:
+			return CurrentIdle, 0, nil
+		}
+	}
+
+	charge := floatvar(vars, "battery.current.charge")
+	discharge := floatvar(vars, "battery.current.discharge")
+	if (charge != nil) || (discharge != nil) {
+		if (charge != nil) && (*charge > 0) {
+			return CurrentCharging, *charge, nil
+		}
+		if (discharge != nil) && (*discharge > 0) {
+			return CurrentDischarging, *discharge, nil
+		}
+		return CurrentIdle, 0, nil
+	}
+
+	result, ok := vars["ups.status"]
+	if !ok {
+		return CurrentIdle, 0, errors.New("Cannot identify battery current direction")
+	}
+
+	status := ParseUpsStatus(result)
+	switch {
+	case status.Charging:
+		return CurrentCharging, 0, nil
+	case status.Discharging:
+		return CurrentDischarging, 0, nil
+	}
+	return CurrentIdle, 0, nil
+}
+
+// Return input current harmonic n (percent)
+func (c *Client) InputCurrentHarmonic(n int) (float64, error) {
+	return c.getfloatdata("input.current.harmonic."+strconv.Itoa(n), "input current harmonic "+strconv.Itoa(n))
+}
+
+// Return all input current harmonics reported by the ups (percent), indexed by rank
+func (c *Client) ListInputCurrentHarmonics() (map[int]float64, error) {
+	retmap := make(map[int]float64)
+
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range vars {
+		if !strings.HasPrefix(name, "input.current.harmonic.") {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(name, "input.current.harmonic."))
+		if err != nil {
+			continue
+		}
+		if value := floatvar(vars, name); value != nil {
+			retmap[n] = *value
+		}
+	}
+	return retmap, nil
+}
+
+// A PowerRating gathers the nominal power specifications of an ups.
+// Ratings not reported by the driver are left to zero.
+type PowerRating struct {
+	PowerNominal         float64 // VA
+	RealPowerNominal     float64 // W
+	InputVoltageNominal  float64 // V
+	InputCurrentNominal  float64 // A
+	OutputCurrentNominal float64 // A
+}
+
+// PowerFactor returns the nominal power factor (real power / apparent power)
+func (r *PowerRating) PowerFactor() float64 {
+	if r.PowerNominal == 0 {
+		return 0
+	}
+	return r.RealPowerNominal / r.PowerNominal
+}
+
+// String returns a human readable label, as in "1500 VA / 1350 W (PF 0.90)"
+func (r *PowerRating) String() string {
+	return fmt.Sprintf("%.0f VA / %.0f W (PF %.2f)", r.PowerNominal, r.RealPowerNominal, r.PowerFactor())
+}
+
+// Return nominal power specifications of current ups
+func (c *Client) GetPowerRating() (*PowerRating, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	value := func(names ...string) float64 {
+		for _, name := range names {
+			if v := floatvar(vars, name); v != nil {
+				return *v
+			}
+		}
+		return 0
+	}
+
+	rating := &PowerRating{
+		PowerNominal:         value("ups.power.nominal"),
+		RealPowerNominal:     value("ups.realpower.nominal"),
+		InputVoltageNominal:  value("ups.input.voltage.nominal", "input.voltage.nominal"),
+		InputCurrentNominal:  value("input.current.nominal"),
+		OutputCurrentNominal: value("output.current.nominal"),
+	}
+	return rating, nil
+}
+
+// Human readable descriptions of ups.status tokens
+var statusDescriptions = map[string]string{
+	"OL":      "Online",
+	"OB":      "On Battery",
+	"LB":      "Low Battery",
+	"HB":      "High Battery",
+	"RB":      "Replace Battery",
+	"CHRG":    "Charging",
+	"DISCHRG": "Discharging",
+	"BYPASS":  "On Bypass",
+	"CAL":     "Calibrating",
+	"OFF":     "Offline",
+	"OVER":    "Overloaded",
+	"TRIM":    "Trimming Voltage",
+	"BOOST":   "Boosting Voltage",
+	"FSD":     "Forced Shutdown",
+	"ALARM":   "Alarm",
+	"TEST":    "Testing",
+}
+
+// StatusTokenDescription returns a human readable description of a ups.status
+// token, or the token itself when it is unknown
+func StatusTokenDescription(token string) string {
+	if description, ok := statusDescriptions[strings.ToUpper(token)]; ok {
+		return description
+	}
+	return token
+}
+
+// Return current ups status as human readable text, as in "Online, Charging"
+func (c *Client) StatusPrettyString() (string, error) {
+	status, err := c.GetUpsStatus()
+	if err != nil {
+		return "", err
+	}
+
+	var descriptions []string
+	for _, token := range strings.Fields(status.Raw) {
+		descriptions = append(descriptions, StatusTokenDescription(token))
+	}
+	return strings.Join(descriptions, ", "), nil
+}
+
+// Return current apparent power delivered to the load (VA), from output.power or ups.power
+func (c *Client) GetOutputApparentPowerAny() (float64, error) {
+	power, err := c.getfloatdata("output.power", "output apparent power")
+	if !errors.Is(err, ErrUnknownVariable) {
+		return power, err
+	}
+	return c.getfloatdata("ups.power", "ups apparent power")
+}
+
+// Return current real power delivered to the load (W), from output.realpower or ups.realpower
+func (c *Client) GetOutputRealPowerAny() (float64, error) {
+	power, err := c.getfloatdata("output.realpower", "output real power")
+	if !errors.Is(err, ErrUnknownVariable) {
+		return power, err
+	}
+	return c.getfloatdata("ups.realpower", "ups real power")
+}
+
+// Date formats used by drivers for date variables
+var dateFormats = []string{"01/02/2006", "2006-01-02", "2006/01/02", "01/2006", "2006"}
+
+// Parse a date as reported by a driver
+func parsedate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, format := range dateFormats {
+		date, err := time.Parse(format, value)
+		if err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, errors.New("Cannot parse date " + value)
+}
+
+// Return Battery date (battery installation or last change date), as reported by the driver
+func (c *Client) BatteryDate() (string, error) {
+	return c.getstringdata("battery.date", "battery date")
+}
+
+// Return Battery date parsed as a time.Time
+func (c *Client) BatteryDateParsed() (time.Time, error) {
+	result, err := c.BatteryDate()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parsedate(result)
+}
+
+// Return Battery age (days) computed from battery date
+func (c *Client) BatteryAgeDays() (int, error) {
+	date, err := c.BatteryDateParsed()
+	if err != nil {
+		return -1, err
+	}
+	return int(time.Since(date).Hours() / 24), nil
+}
+
+// Return maximum input current seen since last reset (A)
+func (c *Client) InputCurrentMaximum() (float64, error) {
+	return c.getfloatdata("input.current.maximum", "input current maximum")
+}
+
+// Return minimum input current seen since last reset (A)
+func (c *Client) InputCurrentMinimum() (float64, error) {
+	return c.getfloatdata("input.current.minimum", "input current minimum")
+}
+
+// Return maximum input voltage seen since last reset (V)
+func (c *Client) InputVoltageMaximumSeen() (float64, error) {
+	return c.getfloatdata("input.voltage.maximum", "input voltage maximum")
+}
+
+// Return minimum input voltage seen since last reset (V)
+func (c *Client) InputVoltageMinimumSeen() (float64, error) {
+	return c.getfloatdata("input.voltage.minimum", "input voltage minimum")
+}
+
+// Reset input minimum and maximum counters (where supported by the driver)
+func (c *Client) ResetInputMinMax() error {
+	return c.RunInstantCommand("reset.input.minmax")
+}
+
+// Return peak apparent output power since last reset (VA)
+func (c *Client) OutputPowerMaximum() (float64, error) {
+	return c.getfloatdata("output.power.maximum", "output power maximum")
+}
+
+// Return peak real output power since last reset (W)
+func (c *Client) OutputRealPowerMaximum() (float64, error) {
+	return c.getfloatdata("output.realpower.maximum", "output real power maximum")
+}
+
+// Reset output minimum and maximum counters (where supported by the driver)
+func (c *Client) ResetOutputMinMax() error {
+	return c.RunInstantCommand("reset.output.minmax")
+}
+
+// An InputCapabilityInfo describes the input power accepted by an ups.
+// Values not reported by the driver are left empty or nil.
+type InputCapabilityInfo struct {
+	AcceptableVoltageRange   string // as in "160-290"
+	AcceptableFrequencyRange string // as in "45-65"
+	BoostHighThreshold       *float64
+	TrimLowThreshold         *float64
+}
+
+// Return input power requirements of current ups
+func (c *Client) GetInputCapabilityInfo() (*InputCapabilityInfo, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &InputCapabilityInfo{
+		AcceptableVoltageRange:   vars["input.voltage.range"],
+		AcceptableFrequencyRange: vars["input.frequency.range"],
+		BoostHighThreshold:       floatvar(vars, "input.transfer.boost.high"),
+		TrimLowThreshold:         floatvar(vars, "input.transfer.trim.low"),
+	}
+	return info, nil
+}
+
+// Return ups internal clock, combining ups.date and ups.time, same as SystemTime
+func (c *Client) UpsDate() (time.Time, error) {
+	return c.SystemTime()
+}
+
+// Set ups internal clock time of day, with the layout set by WithClockFormats
+func (c *Client) SetUpsTime(t time.Time) error {
+	return c.SetVar("ups.time", t.In(c.location).Format(c.timeFormat))
+}
+
+// Return output frequency slew rate (Hz/s).
+// ErrUnknownVariable is returned when the ups does not report it.
+func (c *Client) OutputFrequencySlew() (float64, error) {
+	return c.getfloatdata("output.frequency.slew", "output frequency slew")
+}
+
+// Return the absolute difference between output and input frequency (Hz),
+// an approximation of frequency instability when slew rate is not available
+func (c *Client) GetFrequencyDeviation() (float64, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return -1, err
+	}
+
+	output := floatvar(vars, "output.frequency")
+	input := floatvar(vars, "input.frequency")
+	if (output == nil) || (input == nil) {
+		return -1, ErrUnknownVariable
+	}
+	return math.Abs(*output - *input), nil
+}
+
+// Return true if battery is in a protection or conditioning cycle
+func (c *Client) BatteryProtectionActive() (bool, error) {
+	return c.getbooldata("battery.protection", "battery protection")
+}
+
+// Return Battery condition as reported by the driver ("good", "weak", "replace")
+func (c *Client) BatteryCondition() (string, error) {
+	return c.getstringdata("battery.condition", "battery condition")
+}
+
+// Return true if battery condition is good
+func (c *Client) BatteryConditionGood() (bool, error) {
+	condition, err := c.BatteryCondition()
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(strings.TrimSpace(condition), "good"), nil
+}
+
+// Return driver configuration parameters, without the driver.parameter. prefix
+func (c *Client) GetDriverParameterMap() (map[string]string, error) {
+	retmap := make(map[string]string)
+
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	for name, value := range vars {
+		if strings.HasPrefix(name, "driver.parameter.") {
+			retmap[strings.TrimPrefix(name, "driver.parameter.")] = value
+		}
+	}
+	return retmap, nil
+}
+
+// Return the time left before the ups reaches its low runtime threshold (minutes),
+// computed as (battery.runtime - battery.runtime.low) / 60.
+// When battery.runtime is not reported, the window is estimated from
+// battery.charge and battery.runtime.nominal, and warning explains it.
+func (c *Client) ShutdownWindowMinutes() (minutes float64, warning string, err error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return -1, "", err
+	}
+
+	low := 0.0
+	if value := floatvar(vars, "battery.runtime.low"); value != nil {
+		low = *value
+	}
+
+	runtime := floatvar(vars, "battery.runtime")
+	if runtime == nil {
+		charge := floatvar(vars, "battery.charge")
+		nominal := floatvar(vars, "battery.runtime.nominal")
+		if (charge == nil) || (nominal == nil) {
+			return -1, "", errors.New("Cannot compute shutdown window without battery runtime")
+		}
+		estimate := *charge / 100 * *nominal
+		runtime = &estimate
+		warning = "battery.runtime not reported, shutdown window estimated from battery charge and nominal runtime"
+	}
+
+	return math.Max(*runtime-low, 0) / 60, warning, nil
+}
+
+// Return maximum output current seen since last reset (A)
+func (c *Client) OutputCurrentMaximum() (float64, error) {
+	return c.getfloatdata("output.current.maximum", "output current maximum")
+}
+
+// Return maximum output current on phase L1 seen since last reset (A)
+func (c *Client) OutputL1CurrentMaximum() (float64, error) {
+	return c.getfloatdata("output.L1.current.maximum", "output L1 current maximum")
+}
+
+// Return maximum output current on phase L2 seen since last reset (A)
+func (c *Client) OutputL2CurrentMaximum() (float64, error) {
+	return c.getfloatdata("output.L2.current.maximum", "output L2 current maximum")
+}
+
+// Return maximum output current on phase L3 seen since last reset (A)
+func (c *Client) OutputL3CurrentMaximum() (float64, error) {
+	return c.getfloatdata("output.L3.current.maximum", "output L3 current maximum")
+}
+
+// Return ambient temperature compensation applied to battery charge voltage
+func (c *Client) AmbientTemperatureCompensation() (float64, error) {
+	return c.getfloatdata("ambient.temperature.compensation", "ambient temperature compensation")
+}
+
+// Set ambient temperature compensation applied to battery charge voltage
+func (c *Client) SetAmbientTemperatureCompensation(value float64) error {
+	return c.SetVar("ambient.temperature.compensation", strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+// Return true if ambient humidity compensation is enabled
+func (c *Client) AmbientHumidityCompensationEnabled() (bool, error) {
+	return c.getbooldata("ambient.humidity.compensation", "ambient humidity compensation")
+}
+
+// Return the number of outlets of current ups
+func (c *Client) GetOutletCount() (int, error) {
+	return c.getintdata("outlet.count", "outlet count")
+}
+
+// Return apparent power of outlet n (VA)
+func (c *Client) OutletApparentPower(n int) (float64, error) {
+	return c.getfloatdata("outlet."+strconv.Itoa(n)+".power", "outlet "+strconv.Itoa(n)+" apparent power")
+}
+
+// Return real power of outlet n (W)
+func (c *Client) OutletRealPower(n int) (float64, error) {
+	return c.getfloatdata("outlet."+strconv.Itoa(n)+".realpower", "outlet "+strconv.Itoa(n)+" real power")
+}
+
+// Return apparent power of every outlet (VA), indexed by outlet number.
+// Outlets that do not report their power are left out.
+func (c *Client) GetAllOutletPower() (map[int]float64, error) {
+	retmap := make(map[int]float64)
+
+	count, err := c.GetOutletCount()
+	if err != nil {
+		return nil, err
+	}
+
+	for n := 1; n <= count; n++ {
+		power, err := c.OutletApparentPower(n)
+		if errors.Is(err, ErrUnknownVariable) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		retmap[n] = power
+	}
+	return retmap, nil
+}
+
+// Return measured input current (A)
+func (c *Client) InputCurrentMeasured() (float64, error) {
+	return c.getfloatdata("input.current", "input current")
+}
+
+// Return ups load averaged over 1 minute (percent)
+func (c *Client) UpsLoad1Min() (float64, error) {
+	return c.getfloatdata("ups.load.1min", "ups load 1 minute average")
+}
+
+// Return ups load averaged over 5 minutes (percent)
+func (c *Client) UpsLoad5Min() (float64, error) {
+	return c.getfloatdata("ups.load.5min", "ups load 5 minutes average")
+}
+
+// Return ups load averaged over 15 minutes (percent)
+func (c *Client) UpsLoad15Min() (float64, error) {
+	return c.getfloatdata("ups.load.15min", "ups load 15 minutes average")
+}
+
+// LoadAverages holds ups load averages (percent). Averages not reported are left nil.
+type LoadAverages struct {
+	Load1Min  *float64
+	Load5Min  *float64
+	Load15Min *float64
+}
+
+// Return ups load averages, fetched in a single call
+func (c *Client) GetLoadAverages() (*LoadAverages, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	averages := &LoadAverages{
+		Load1Min:  floatvar(vars, "ups.load.1min"),
+		Load5Min:  floatvar(vars, "ups.load.5min"),
+		Load15Min: floatvar(vars, "ups.load.15min"),
+	}
+	return averages, nil
+}
+
+// Return minimum output voltage seen since last reset (V)
+func (c *Client) OutputVoltageMinimum() (float64, error) {
+	return c.getfloatdata("output.voltage.minimum", "output voltage minimum")
+}
+
+// Return maximum output voltage seen since last reset (V)
+func (c *Client) OutputVoltageMaximum() (float64, error) {
+	return c.getfloatdata("output.voltage.maximum", "output voltage maximum")
+}
+
+// Return minimum input voltage seen since last reset (V), same as InputVoltageMinimumSeen
+func (c *Client) InputVoltageMinimum() (float64, error) {
+	return c.InputVoltageMinimumSeen()
+}
+
+// Return maximum input voltage seen since last reset (V), same as InputVoltageMaximumSeen
+func (c *Client) InputVoltageMaximum() (float64, error) {
+	return c.InputVoltageMaximumSeen()
+}
+
+// A ChargingMode is the battery charger mode reported in ups.charge.mode
+type ChargingMode int
+
+const (
+	ChargingModeUnknown ChargingMode = iota
+	ChargingModeFloat
+	ChargingModeBulk
+	ChargingModeAbsorption
+	ChargingModeEqualize
+	ChargingModeOff
+)
+
+// ParseChargeMode converts a ups.charge.mode value to a ChargingMode
+func ParseChargeMode(s string) ChargingMode {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "float":
+		return ChargingModeFloat
+	case "bulk":
+		return ChargingModeBulk
+	case "absorption":
+		return ChargingModeAbsorption
+	case "equalize":
+		return ChargingModeEqualize
+	case "off":
+		return ChargingModeOff
+	}
+	return ChargingModeUnknown
+}
+
+// Return battery charger mode ("float", "bulk", "absorption", "equalize", "off")
+func (c *Client) ChargeMode() (string, error) {
+	return c.getstringdata("ups.charge.mode", "ups charge mode")
+}
+
+// Return true if a fully charged battery is being maintained (float charge)
+func (c *Client) IsFloatCharging() (bool, error) {
+	mode, err := c.ChargeMode()
+	if err != nil {
+		return false, err
+	}
+	return ParseChargeMode(mode) == ChargingModeFloat, nil
+}
+
+// Return true if battery is actively charging (bulk charge)
+func (c *Client) IsBulkCharging() (bool, error) {
+	mode, err := c.ChargeMode()
+	if err != nil {
+		return false, err
+	}
+	return ParseChargeMode(mode) == ChargingModeBulk, nil
+}
+
+// Return IPv6 address of the ups network management card
+func (c *Client) NetcardIPv6Address() (string, error) {
+	address, err := c.getstringdata("netcard.ip.ipv6.address", "netcard IPv6 address")
+	if !errors.Is(err, ErrUnknownVariable) {
+		return address, err
+	}
+	return c.getstringdata("netcard.ipv6.address", "netcard IPv6 address")
+}
+
+// NetworkCardAddresses holds the addresses of the ups network management card.
+// Addresses not reported are left empty.
+type NetworkCardAddresses struct {
+	IPv4 string
+	IPv6 string
+}
+
+// Return addresses of the ups network management card, fetched in a single call
+func (c *Client) GetNetworkCardAddresses() (*NetworkCardAddresses, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	first := func(names ...string) string {
+		for _, name := range names {
+			if value, ok := vars[name]; ok {
+				return value
+			}
+		}
+		return ""
+	}
+
+	addresses := &NetworkCardAddresses{
+		IPv4: first("netcard.ip.address", "netcard.ipv4.address"),
+		IPv6: first("netcard.ip.ipv6.address", "netcard.ipv6.address"),
+	}
+	return addresses, nil
+}
+
+// Return the bypass path in use ("manual", "automatic", "maintenance")
+func (c *Client) BypassSource() (string, error) {
+	return c.getstringdata("ups.bypass.source", "bypass source")
+}
+
+// Return bypass voltage (V)
+func (c *Client) BypassVoltage() (float64, error) {
+	return c.getfloatdata("ups.bypass.voltage", "bypass voltage")
+}
+
+// Return bypass current (A)
+func (c *Client) BypassCurrent() (float64, error) {
+	return c.getfloatdata("ups.bypass.current", "bypass current")
+}
+
+// Return bypass frequency (Hz)
+func (c *Client) BypassFrequency() (float64, error) {
+	return c.getfloatdata("ups.bypass.frequency", "bypass frequency")
+}
+
+// BypassInfo holds the bypass path state. Values not reported are left empty or nil.
+type BypassInfo struct {
+	Source    string
+	Voltage   *float64
+	Current   *float64
+	Frequency *float64
+}
+
+// Return bypass path state, fetched in a single call
+func (c *Client) GetBypassInfo() (*BypassInfo, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &BypassInfo{
+		Source:    vars["ups.bypass.source"],
+		Voltage:   floatvar(vars, "ups.bypass.voltage"),
+		Current:   floatvar(vars, "ups.bypass.current"),
+		Frequency: floatvar(vars, "ups.bypass.frequency"),
+	}
+	return info, nil
+}
+
+// Return ups fuse status
+func (c *Client) FuseStatus() (string, error) {
+	return c.getstringdata("ups.fuse.status", "fuse status")
+}
+
+// Return ups circuit breaker status
+func (c *Client) CircuitBreakerStatus() (string, error) {
+	return c.getstringdata("ups.circuit.breaker.status", "circuit breaker status")
+}
+
+// Return ups maintenance bypass switch status
+func (c *Client) MaintenanceBypassStatus() (string, error) {
+	return c.getstringdata("ups.maintenance.bypass.status", "maintenance bypass status")
+}
+
+// MechanicalStatus holds the mechanical status indicators of an ups.
+// Indicators not reported are left nil.
+type MechanicalStatus struct {
+	Fuse              *string
+	CircuitBreaker    *string
+	MaintenanceBypass *string
+}
+
+// Return a pointer to the value of a var, or nil if absent
+func stringvar(vars map[string]string, name string) *string {
+	value, ok := vars[name]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+// Return mechanical status indicators, fetched in a single call
+func (c *Client) GetMechanicalStatus() (*MechanicalStatus, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &MechanicalStatus{
+		Fuse:              stringvar(vars, "ups.fuse.status"),
+		CircuitBreaker:    stringvar(vars, "ups.circuit.breaker.status"),
+		MaintenanceBypass: stringvar(vars, "ups.maintenance.bypass.status"),
+	}
+	return status, nil
+}
+
+// Return the values allowed for an enumerated variable of current ups
+func (c *Client) ListEnumValues(variable string) ([]string, error) {
+	var retslice []string
+
+	if len([]rune(c.upsName)) == 0 {
+		return nil, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	if len(variable) == 0 {
+		return nil, errors.New("Variable cannot be empty")
+	}
+
+	result, err := c.getmultilinesdata("LIST ENUM " + c.upsName + " " + variable)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error getting enum values of %s: %w", variable, err)
+	}
+
+	for _, value := range result {
+		retcode, _, _ := strings.Cut(value, " ")
+
+		if strings.EqualFold(retcode, "ENUM") {
+			retslice = append(retslice, quotedvalue(value))
+		}
+	}
+	return retslice, nil
+}
+
+// Return ups display language
+func (c *Client) DisplayLanguage() (string, error) {
+	return c.getstringdata("ups.display.language", "display language")
+}
+
+// Set ups display language
+func (c *Client) SetDisplayLanguage(lang string) error {
+	return c.SetVar("ups.display.language", lang)
+}
+
+// Return languages supported by ups display
+func (c *Client) ListDisplayLanguages() ([]string, error) {
+	return c.ListEnumValues("ups.display.language")
+}
+
+// Return ups display scroll interval (seconds)
+func (c *Client) DisplayScrollInterval() (int, error) {
+	return c.getintdata("ups.display.scroll.interval", "display scroll interval")
+}
+
+// Set ups display scroll interval (seconds)
+func (c *Client) SetDisplayScrollInterval(seconds int) error {
+	return c.SetVar("ups.display.scroll.interval", strconv.Itoa(seconds))
+}
+
+// Return true if ups status reports the battery must be replaced (RB)
+func (c *Client) NeedsBatteryReplacement() (bool, error) {
+	status, err := c.GetUpsStatus()
+	if err != nil {
+		return false, err
+	}
+	return status.ReplaceBattery, nil
+}
+
+// Return the scheduled battery replacement date, or nil if the driver does not report one
+func (c *Client) BatteryReplacementDue() (*time.Time, error) {
+	result, err := c.getstringdata("battery.replace.date", "battery replacement date")
+	if errors.Is(err, ErrUnknownVariable) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	date, err := parsedate(result)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+// Schedule battery replacement date, written with the date layout set by WithClockFormats
+func (c *Client) SetBatteryReplacementDate(t time.Time) error {
+	return c.SetVar("battery.replace.date", t.In(c.location).Format(c.dateFormat))
+}
+
+// Return power factor of the ups as seen by the mains
+func (c *Client) InputPowerFactor() (float64, error) {
+	return c.getfloatdata("input.powerfactor", "input power factor")
+}
+
+// Return power factor of the load served by the ups
+func (c *Client) OutputPowerFactor() (float64, error) {
+	return c.getfloatdata("output.powerfactor", "output power factor")
+}
+
+// Return input power factor / output power factor, showing how much power
+// factor correction the ups provides
+func (c *Client) PowerFactorBalance() (float64, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return -1, err
+	}
+
+	input := floatvar(vars, "input.powerfactor")
+	output := floatvar(vars, "output.powerfactor")
+	if (input == nil) || (output == nil) {
+		return -1, ErrUnknownVariable
+	}
+	if *output == 0 {
+		return -1, errors.New("Output power factor is zero")
+	}
+	return *input / *output, nil
+}
+
+// Return mains frequency detected by the ups (Hz)
+func (c *Client) InputFrequencyDetected() (float64, error) {
+	return c.getfloatdata("input.frequency.detected", "input frequency detected")
+}
+
+// Return false if detected mains frequency does not match the ups nominal
+// input frequency, as for a 50 Hz unit plugged on 60 Hz mains
+func (c *Client) IsGlobalCompatible() (bool, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return false, err
+	}
+
+	detected := floatvar(vars, "input.frequency.detected")
+	nominal := floatvar(vars, "input.frequency.nominal")
+	if (detected == nil) || (nominal == nil) {
+		return false, ErrUnknownVariable
+	}
+	// 50 Hz and 60 Hz mains are 10 Hz apart, anything closer than 5 Hz is the same class
+	return math.Abs(*detected-*nominal) < 5, nil
+}
+
+// Return NTP server used by the ups to synchronize its clock
+func (c *Client) NTPServer() (string, error) {
+	return c.getstringdata("ups.ntpsrv", "ntp server")
+}
+
+// Set NTP server used by the ups to synchronize its clock
+func (c *Client) SetNTPServer(addr string) error {
+	return c.SetVar("ups.ntpsrv", addr)
+}
+
+// Return true if ups clock synchronization through NTP is enabled
+func (c *Client) NTPSyncEnabled() (bool, error) {
+	return c.getbooldata("ups.ntpsync", "ntp sync")
+}
+
+// Return ups standby (eco) mode ("on", "off", "auto")
+func (c *Client) StandbyMode() (string, error) {
+	return c.getstringdata("ups.standby.mode", "standby mode")
+}
+
+// Set ups standby (eco) mode ("on", "off", "auto")
+func (c *Client) SetStandbyMode(mode string) error {
+	return c.SetVar("ups.standby.mode", mode)
+}
+
+// Return true if standby (eco) mode is "on" or "auto"
+func (c *Client) EcoModeEnabled() (bool, error) {
+	mode, err := c.StandbyMode()
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "on", "auto":
+		return true, nil
+	}
+	return false, nil
+}
+
+// Return DC output voltage (V), independently of AC output voltage on hybrid ups
+func (c *Client) OutputVoltageDC() (float64, error) {
+	return c.getfloatdata("output.voltage.DC", "DC output voltage")
+}
+
+// Return DC output current (A), independently of AC output current on hybrid ups
+func (c *Client) OutputCurrentDC() (float64, error) {
+	return c.getfloatdata("output.current.DC", "DC output current")
+}
+
+// Return DC battery voltage (V)
+func (c *Client) BatteryVoltageDC() (float64, error) {
+	return c.getfloatdata("battery.voltage.DC", "DC battery voltage")
+}
+
+// Return ups health indicator
+func (c *Client) UpsHealth() (string, error) {
+	return c.getstringdata("ups.health", "ups health")
+}
+
+// Return ups health summary
+func (c *Client) UpsHealthSummary() (string, error) {
+	return c.getstringdata("ups.health.summary", "ups health summary")
+}
+
+// Return battery health indicator
+func (c *Client) BatteryHealthStatus() (string, error) {
+	return c.getstringdata("battery.health", "battery health")
+}
+
+// Return ups service request indicator
+func (c *Client) ServiceRequest() (string, error) {
+	return c.getstringdata("ups.service.request", "ups service request")
+}
+
+// Return true if the ups requests service or a health indicator reports degradation.
+// Health values other than "ok", "good" or "normal" are considered degraded.
+func (c *Client) NeedsService() (bool, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return false, err
+	}
+
+	if strings.EqualFold(strings.TrimSpace(vars["ups.service.request"]), "yes") {
+		return true, nil
+	}
+
+	for _, name := range []string{"ups.health", "ups.health.summary", "battery.health"} {
+		value, ok := vars[name]
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "ok", "good", "normal":
+		default:
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Return delay before outlet n is automatically enabled
+func (c *Client) OutletAutoEnableDelay(n int) (time.Duration, error) {
+	seconds, err := c.getintdata("outlet."+strconv.Itoa(n)+".autoenable.delay", "outlet "+strconv.Itoa(n)+" auto enable delay")
+	if err != nil {
+		return -1, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// Set delay before outlet n is automatically enabled (truncated to the second)
+func (c *Client) SetOutletAutoEnableDelay(n int, delay time.Duration) error {
+	return c.SetVar("outlet."+strconv.Itoa(n)+".autoenable.delay", strconv.Itoa(int(delay/time.Second)))
+}
+
+// Return delay before outlet n is automatically disabled
+func (c *Client) OutletAutoDisableDelay(n int) (time.Duration, error) {
+	seconds, err := c.getintdata("outlet."+strconv.Itoa(n)+".autodisable.delay", "outlet "+strconv.Itoa(n)+" auto disable delay")
+	if err != nil {
+		return -1, err
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// Set delay before outlet n is automatically disabled (truncated to the second)
+func (c *Client) SetOutletAutoDisableDelay(n int, delay time.Duration) error {
+	return c.SetVar("outlet."+strconv.Itoa(n)+".autodisable.delay", strconv.Itoa(int(delay/time.Second)))
+}
+
+// Return unit of ups temperature ("C" or "F"), "C" when not reported by the driver
+func (c *Client) UpsTemperatureUnit() (string, error) {
+	unit, err := c.getstringdata("ups.temperature.unit", "ups temperature unit")
+	if errors.Is(err, ErrUnknownVariable) {
+		return "C", nil
+	} else if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(strings.TrimSpace(unit)), nil
+}
+
+// Return ups temperature (degrees F). The value is converted only when the
+// driver reports it in Celsius.
+func (c *Client) UpsTemperatureF() (float64, error) {
+	temperature, err := c.getfloatdata("ups.temperature", "ups temperature")
+	if err != nil {
+		return -1, err
+	}
+
+	unit, err := c.UpsTemperatureUnit()
+	if err != nil {
+		return -1, err
+	}
+
+	if strings.HasPrefix(unit, "F") {
+		return temperature, nil
+	}
+	return temperature*9/5 + 32, nil
+}
+
+// Return battery charger type ("linear", "switchmode", "smart")
+func (c *Client) ChargerType() (string, error) {
+	return c.getstringdata("charger.type", "charger type")
+}
+
+// Return battery charger status ("standby", "charging", "full")
+func (c *Client) ChargerStatus() (string, error) {
+	return c.getstringdata("charger.status", "charger status")
+}
+
+// Return true if battery charger type is "smart"
+func (c *Client) IsSmartCharger() (bool, error) {
+	chargertype, err := c.ChargerType()
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(strings.TrimSpace(chargertype), "smart"), nil
+}
+
+// Return true if ups output has been shut down by over-temperature protection
+func (c *Client) OvertemperatureProtectionActive() (bool, error) {
+	return c.getbooldata("ups.overtemperature.protection", "overtemperature protection")
+}
+
+// Return true if ups.alarm reports a temperature related alarm.
+// No alarm is reported when the driver does not expose ups.alarm.
+func (c *Client) UpsTemperatureAlarm() (bool, error) {
+	alarm, err := c.getstringdata("ups.alarm", "ups alarm")
+	if errors.Is(err, ErrUnknownVariable) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	alarm = strings.ToLower(alarm)
+	for _, keyword := range []string{"temperature", "overheat", "thermal"} {
+		if strings.Contains(alarm, keyword) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Return total harmonic distortion of input voltage (percent)
+func (c *Client) InputVoltageTHD() (float64, error) {
+	return c.getfloatdata("input.voltage.THD", "input voltage THD")
+}
+
+// Return total harmonic distortion of input current (percent)
+func (c *Client) InputCurrentTHD() (float64, error) {
+	return c.getfloatdata("input.current.THD", "input current THD")
+}
+
+// Return total harmonic distortion of output voltage (percent)
+func (c *Client) OutputVoltageTHD() (float64, error) {
+	return c.getfloatdata("output.voltage.THD", "output voltage THD")
+}
+
+// HarmonicDistortion holds total harmonic distortion measurements (percent).
+// Measurements not reported are left nil.
+type HarmonicDistortion struct {
+	InputVoltage  *float64
+	InputCurrent  *float64
+	OutputVoltage *float64
+}
+
+// Return total harmonic distortion measurements, fetched in a single call
+func (c *Client) GetHarmonicDistortionSummary() (*HarmonicDistortion, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &HarmonicDistortion{
+		InputVoltage:  floatvar(vars, "input.voltage.THD"),
+		InputCurrent:  floatvar(vars, "input.current.THD"),
+		OutputVoltage: floatvar(vars, "output.voltage.THD"),
+	}
+	return summary, nil
+}
+
+// Return ups id in its parallel group
+func (c *Client) ParallelID() (int, error) {
+	return c.getintdata("ups.parallel.id", "parallel id")
+}
+
+// Return number of ups in the parallel group
+func (c *Client) ParallelCount() (int, error) {
+	return c.getintdata("ups.parallel.count", "parallel count")
+}
+
+// Return parallel group synchronization status
+func (c *Client) ParallelSyncStatus() (string, error) {
+	return c.getstringdata("ups.parallel.synchronization", "parallel synchronization status")
+}
+
+// Return true if the parallel group holds more than one ups and is synchronized
+func (c *Client) IsRedundant() (bool, error) {
+	count, err := c.ParallelCount()
+	if err != nil {
+		return false, err
+	}
+	if count <= 1 {
+		return false, nil
+	}
+
+	status, err := c.ParallelSyncStatus()
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "ok", "yes", "synchronized", "synced":
+		return true, nil
+	}
+	return false, nil
+}
+
+// Return number of power modules of a modular ups
+func (c *Client) ModuleCount() (int, error) {
+	return c.getintdata("ups.module.count", "module count")
+}
+
+// Return status of power module n
+func (c *Client) ModuleStatus(n int) (string, error) {
+	return c.getstringdata("ups.module."+strconv.Itoa(n)+".status", "module "+strconv.Itoa(n)+" status")
+}
+
+// Return power of power module n
+func (c *Client) ModulePower(n int) (float64, error) {
+	return c.getfloatdata("ups.module."+strconv.Itoa(n)+".power", "module "+strconv.Itoa(n)+" power")
+}
+
+// Return temperature of power module n (degrees C)
+func (c *Client) ModuleTemperature(n int) (float64, error) {
+	return c.getfloatdata("ups.module."+strconv.Itoa(n)+".temperature", "module "+strconv.Itoa(n)+" temperature")
+}
+
+// ModuleInfo holds the state of a power module. Values not reported are left empty or nil.
+type ModuleInfo struct {
+	Index       int
+	Status      string
+	Power       *float64
+	Temperature *float64
+}
+
+// Return the state of every power module, sorted by index and fetched in a single call
+func (c *Client) GetAllModuleInfo() ([]*ModuleInfo, error) {
+	var retslice []*ModuleInfo
+
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make(map[int]bool)
+	for name := range vars {
+		if !strings.HasPrefix(name, "ups.module.") {
+			continue
+		}
+		index, _, _ := strings.Cut(strings.TrimPrefix(name, "ups.module."), ".")
+		n, err := strconv.Atoi(index)
+		if err == nil {
+			indexes[n] = true
+		}
+	}
+
+	for n := range indexes {
+		prefix := "ups.module." + strconv.Itoa(n) + "."
+		retslice = append(retslice, &ModuleInfo{
+			Index:       n,
+			Status:      vars[prefix+"status"],
+			Power:       floatvar(vars, prefix+"power"),
+			Temperature: floatvar(vars, prefix+"temperature"),
+		})
+	}
+	sort.Slice(retslice, func(i, j int) bool {
+		return retslice[i].Index < retslice[j].Index
+	})
+	return retslice, nil
+}
+
+// A VarRange is a range of values allowed for a numerical variable
+type VarRange struct {
+	Min float64
+	Max float64
+}
+
+// Return the ranges of values allowed for a variable of current ups
+func (c *Client) ListVarRange(variable string) ([]VarRange, error) {
+	var retslice []VarRange
+
+	if len([]rune(c.upsName)) == 0 {
+		return nil, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	if len(variable) == 0 {
+		return nil, errors.New("Variable cannot be empty")
+	}
+
+	result, err := c.getmultilinesdata("LIST RANGE " + c.upsName + " " + variable)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error getting ranges of %s: %w", variable, err)
+	}
+
+	for _, value := range result {
+		retcode, _, _ := strings.Cut(value, " ")
+
+		if strings.EqualFold(retcode, "RANGE") {
+			argsstr := strings.Split(value, "\"")
+			if len(argsstr) < 4 {
+				continue
+			}
+			low, err := strconv.ParseFloat(argsstr[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("Cannot convert range of %s to numerical value: %w", variable, err)
+			}
+			high, err := strconv.ParseFloat(argsstr[3], 64)
+			if err != nil {
+				return nil, fmt.Errorf("Cannot convert range of %s to numerical value: %w", variable, err)
+			}
+			retslice = append(retslice, VarRange{Min: low, Max: high})
+		}
+	}
+	return retslice, nil
+}
+
+// Return target output voltage (V)
+func (c *Client) OutputVoltageTarget() (float64, error) {
+	return c.getfloatdata("output.voltage.target", "output voltage target")
+}
+
+// How long SetOutputVoltageTarget waits for the driver to apply a new target,
+// and how often it reads the target back meanwhile
+const (
+	applyTimeout      = 5 * time.Second
+	applyPollInterval = 250 * time.Millisecond
+)
+
+// Set target output voltage (V).
+// The voltage is checked against the ranges reported by the driver, if any,
+// and ErrValueOutOfRange is returned when it does not fit. As upsd acknowledges
+// SET VAR before the driver applies it, the variable is then read back until it
+// matches, for up to 5 seconds or until the client context is done.
+func (c *Client) SetOutputVoltageTarget(voltage float64) error {
+	ranges, err := c.ListVarRange("output.voltage.target")
+	if (err == nil) && (len(ranges) > 0) {
+		allowed := false
+		for _, r := range ranges {
+			if (voltage >= r.Min) && (voltage <= r.Max) {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return ErrValueOutOfRange
+		}
+	}
+
+	err = c.SetVar("output.voltage.target", strconv.FormatFloat(voltage, 'f', -1, 64))
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	ctx := c.ctx
+	c.mutex.Unlock()
+
+	ticker := time.NewTicker(applyPollInterval)
+	defer ticker.Stop()
+	deadline := time.Now().Add(applyTimeout)
+
+	for {
+		target, err := c.OutputVoltageTarget()
+		if err != nil {
+			return err
+		}
+		if math.Abs(target-voltage) < 0.5 {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return errors.New("Output voltage target not applied")
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Return true if automatic transfer to battery is enabled for outlet group n
+func (c *Client) OutletGroupAutoTransferEnabled(n int) (bool, error) {
+	return c.getbooldata("outlet.group."+strconv.Itoa(n)+".autotransfer.enabled", "outlet group "+strconv.Itoa(n)+" auto transfer")
+}
+
+// Enable or disable automatic transfer to battery for outlet group n
+func (c *Client) SetOutletGroupAutoTransfer(n int, enabled bool) error {
+	value := "no"
+	if enabled {
+		value = "yes"
+	}
+	return c.SetVar("outlet.group."+strconv.Itoa(n)+".autotransfer.enabled", value)
+}
+
+// Return ups real time clock, parsing ups.date and ups.time with the layouts
+// set by WithClockFormats
+func (c *Client) SystemTime() (time.Time, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	upsdate, ok := vars["ups.date"]
+	if !ok {
+		return time.Time{}, errors.New("Error getting current ups date")
+	}
+	upstime, ok := vars["ups.time"]
+	if !ok {
+		return time.Time{}, errors.New("Error getting current ups time")
+	}
+
+	date, err := time.Parse(c.dateFormat, strings.TrimSpace(upsdate))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Cannot parse date %s: %w", upsdate, err)
+	}
+	clock, err := time.Parse(c.timeFormat, strings.TrimSpace(upstime))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Cannot parse time %s: %w", upstime, err)
+	}
+
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, c.location), nil
+}
+
+// Set ups real time clock date and time
+func (c *Client) SetSystemTime(t time.Time) error {
+	t = t.In(c.location)
+
+	err := c.SetVar("ups.date", t.Format(c.dateFormat))
+	if err != nil {
+		return err
+	}
+	return c.SetVar("ups.time", t.Format(c.timeFormat))
+}
+
+// Return surge protection status ("ok", "degraded", "failed"), from ups.surge.status or ups.MOV.status
+func (c *Client) SurgeProtectionStatus() (string, error) {
+	status, err := c.getstringdata("ups.surge.status", "surge protection status")
+	if !errors.Is(err, ErrUnknownVariable) {
+		return status, err
+	}
+	return c.getstringdata("ups.MOV.status", "surge protection status")
+}
+
+// Return true if surge protection status is "ok"
+func (c *Client) IsSurgeProtectionOK() (bool, error) {
+	status, err := c.SurgeProtectionStatus()
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(strings.TrimSpace(status), "ok"), nil
+}
+
+// Return date of last battery replacement, read from battery.date
+func (c *Client) BatteryLastReplaced() (time.Time, error) {
+	return c.BatteryDateParsed()
+}
+
+// Record the battery as replaced today, writing current date to battery.date
+// with the date layout set by WithClockFormats
+func (c *Client) MarkBatteryReplaced() error {
+	return c.SetVar("battery.date", time.Now().In(c.location).Format(c.dateFormat))
+}
+
+// Return true if battery age exceeds threshold
+func (c *Client) BatteryAgeWarning(threshold time.Duration) (bool, error) {
+	date, err := c.BatteryLastReplaced()
+	if err != nil {
+		return false, err
+	}
+	return time.Since(date) > threshold, nil
+}
+
+// Return output waveform type ("pure sine", "simulated sine", "square", "sawtooth")
+func (c *Client) OutputWaveformType() (string, error) {
+	return c.getstringdata("output.waveform.type", "output waveform type")
+}
+
+// Return true if output waveform type is "pure sine"
+func (c *Client) IsPureSineOutput() (bool, error) {
+	waveform, err := c.OutputWaveformType()
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(strings.TrimSpace(waveform), "pure sine"), nil
+}
+
+// Return seconds left before the ups cuts power, or -1 if the shutdown timer is not active
+func (c *Client) ShutdownCountdownSeconds() (int, error) {
+	return c.getintdata("ups.timer.shutdown", "shutdown timer")
+}
+
+// Wait until the shutdown timer reaches zero, polling it at the interval set by
+// WithShutdownPollInterval. ErrShutdownTimerInactive is returned if the timer
+// is not running, and the context error if ctx is done first.
+func (c *Client) WaitForShutdown(ctx context.Context) error {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		seconds, err := c.ShutdownCountdownSeconds()
+		if err != nil {
+			return err
+		}
+		if seconds == 0 {
+			return nil
+		}
+		if seconds < 0 {
+			return ErrShutdownTimerInactive
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// An InputQualityStatus classifies the input power quality
+type InputQualityStatus int
+
+const (
+	// Input voltage and frequency are in their normal range
+	InputQualityGood InputQualityStatus = iota
+	// Input voltage or frequency is out of its normal range but within transfer thresholds
+	InputQualityDegraded
+	// The ups has transferred to battery
+	InputQualityBad
+)
+
+// Return true if frequency is more than 1 Hz away from both 50 Hz and 60 Hz mains
+func offmainsfrequency(frequency float64) bool {
+	return (math.Abs(frequency-50) > 1) && (math.Abs(frequency-60) > 1)
+}
+
+// Classify input power quality from ups vars. The normal voltage range is the
+// transfer window minus 10% of its width on each side.
+func inputquality(vars map[string]string) (InputQualityStatus, error) {
+	result, ok := vars["ups.status"]
+	if !ok {
+		return InputQualityBad, errors.New("Error getting current ups status")
+	}
+
+	status := ParseUpsStatus(result)
+	if status.OnBattery {
+		return InputQualityBad, nil
+	}
+	if status.Trim || status.Boost {
+		return InputQualityDegraded, nil
+	}
+
+	voltage := floatvar(vars, "input.voltage")
+	low := floatvar(vars, "input.transfer.low")
+	high := floatvar(vars, "input.transfer.high")
+	if (voltage != nil) && (low != nil) && (high != nil) {
+		margin := (*high - *low) / 10
+		if (*voltage < *low+margin) || (*voltage > *high-margin) {
+			return InputQualityDegraded, nil
+		}
+	}
+
+	if frequency := floatvar(vars, "input.frequency"); (frequency != nil) && offmainsfrequency(*frequency) {
+		return InputQualityDegraded, nil
+	}
+	return InputQualityGood, nil
+}
+
+// Return input power quality of current ups, fetched in a single call
+func (c *Client) InputQualityStatus() (InputQualityStatus, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return InputQualityBad, err
+	}
+	return inputquality(vars)
+}
+
+// An OutputQualityStatus classifies the output power quality
+type OutputQualityStatus int
+
+const (
+	// Output is fed from mains with voltage and frequency in their normal range
+	OutputQualityNormal OutputQualityStatus = iota
+	// Output is fed from battery
+	OutputQualityDerived
+	// Output voltage or frequency is out of its normal range
+	OutputQualityDegraded
+	// Output is off
+	OutputQualityFailed
+)
+
+// Classify output power quality from ups vars. The normal voltage range is
+// the nominal output voltage plus or minus 10%.
+func outputquality(vars map[string]string) (OutputQualityStatus, error) {
+	result, ok := vars["ups.status"]
+	if !ok {
+		return OutputQualityFailed, errors.New("Error getting current ups status")
+	}
+
+	status := ParseUpsStatus(result)
+	voltage := floatvar(vars, "output.voltage")
+	if status.Off || ((voltage != nil) && (*voltage == 0)) {
+		return OutputQualityFailed, nil
+	}
+	if status.OnBattery {
+		return OutputQualityDerived, nil
+	}
+
+	if nominal := floatvar(vars, "output.voltage.nominal"); (voltage != nil) && (nominal != nil) {
+		if math.Abs(*voltage-*nominal) > *nominal/10 {
+			return OutputQualityDegraded, nil
+		}
+	}
+
+	if frequency := floatvar(vars, "output.frequency"); (frequency != nil) && offmainsfrequency(*frequency) {
+		return OutputQualityDegraded, nil
+	}
+	return OutputQualityNormal, nil
+}
+
+// Return output power quality of current ups, fetched in a single call
+func (c *Client) OutputQualityStatus() (OutputQualityStatus, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return OutputQualityFailed, err
+	}
+	return outputquality(vars)
+}
+
+// PowerStatus holds input and output power quality classifications
+type PowerStatus struct {
+	Input  InputQualityStatus
+	Output OutputQualityStatus
+}
+
+// Return input and output power quality of current ups, fetched in a single call
+func (c *Client) GetPowerStatus() (*PowerStatus, error) {
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := inputquality(vars)
+	if err != nil {
+		return nil, err
+	}
+	output, err := outputquality(vars)
+	if err != nil {
+		return nil, err
+	}
+	return &PowerStatus{Input: input, Output: output}, nil
+}
+
+// Return Input Voltage (V) without truncation
+func (c *Client) InputVoltageFloat() (float64, error) {
+	return c.getfloatdata("input.voltage", "input voltage")
+}
+
+// Send an instant command to current ups, as in "test.battery.start"
+func (c *Client) RunInstantCommand(command string) error {
+	if len([]rune(c.upsName)) == 0 {
+		return errors.New("No UPS defined, use LOGIN first")
+	}
+
+	if len(command) == 0 {
+		return errors.New("Command cannot be empty")
+	}
+
+	_, err := c.cmd("INSTCMD " + c.upsName + " " + command)
+	return err
+}
+
+// Return instant commands available on current ups
+func (c *Client) ListCommands() ([]string, error) {
+	var retslice []string
+
+	if len([]rune(c.upsName)) == 0 {
+		return nil, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.getmultilinesdata("LIST CMD " + c.upsName)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error getting ups commands: %w", err)
+	}
+
+	for _, value := range result {
+		retcode, _, _ := strings.Cut(value, " ")
+
+		if strings.EqualFold(retcode, "CMD") {
+			argsstr := strings.Fields(value)
+			if len(argsstr) > 2 {
+				retslice = append(retslice, argsstr[2])
+			}
+		}
+	}
+	return retslice, nil
+}
+
+// Start a battery test
+func (c *Client) TestBattery() error {
+	return c.RunInstantCommand("test.battery.start")
+}
+
+// Toggle the ups beeper
+func (c *Client) BeeperToggle() error {
+	return c.RunInstantCommand("beeper.toggle")
+}
+
+// Turn off the load and return when power is back
+func (c *Client) ShutdownReturn() error {
+	return c.RunInstantCommand("shutdown.return")
+}
+
+// Return writable vars of current ups
+func (c *Client) GetRWVars() ([]string, error) {
+	var retslice []string
+
+	if len([]rune(c.upsName)) == 0 {
+		return nil, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.getmultilinesdata("LIST RW " + c.upsName)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error getting ups writable vars: %w", err)
+	}
+
+	for _, value := range result {
+		retcode, _, _ := strings.Cut(value, " ")
+
+		if strings.EqualFold(retcode, "RW") {
+			argsstr := strings.Fields(value)
+			if len(argsstr) > 2 {
+				retslice = append(retslice, argsstr[2])
+			}
+		}
+	}
+	return retslice, nil
+}
+
+// Set a writable variable of current ups.
+// An error is returned without sending SET VAR if the variable is not listed in LIST RW.
+func (c *Client) SetVar(variable string, value string) error {
+	rwvars, err := c.GetRWVars()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range rwvars {
+		if name == variable {
+			return c.setvar(variable, value)
+		}
+	}
+	return errors.New("Variable " + variable + " is not writable")
+}
+
+// Return writable vars of current ups with their current values
+func (c *Client) GetRWVarsWithValues() (map[string]string, error) {
+	retmap := make(map[string]string)
+
+	if len([]rune(c.upsName)) == 0 {
+		return nil, errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.getmultilinesdata("LIST RW " + c.upsName)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error getting ups writable vars: %w", err)
+	}
+
+	for _, value := range result {
+		retcode, _, _ := strings.Cut(value, " ")
+
+		if strings.EqualFold(retcode, "RW") {
+			argsstr := strings.Fields(value)
+			if len(argsstr) > 3 {
+				retmap[argsstr[2]] = quotedvalue(value)
+			}
+		}
+	}
+	return retmap, nil
+}
+
+// Return the values allowed for an enumerated variable of current ups, same as ListEnumValues
+func (c *Client) GetVarEnum(variable string) ([]string, error) {
+	return c.ListEnumValues(variable)
+}
+
+// Return the lowest and highest values allowed for a numerical variable of
+// current ups. When the driver reports several ranges, the bounds span all of them.
+func (c *Client) GetVarRange(variable string) (min, max float64, err error) {
+	ranges, err := c.ListVarRange(variable)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(ranges) == 0 {
+		return 0, 0, errors.New("No range defined for " + variable)
+	}
+
+	min, max = ranges[0].Min, ranges[0].Max
+	for _, r := range ranges[1:] {
+		min = math.Min(min, r.Min)
+		max = math.Max(max, r.Max)
+	}
+	return min, max, nil
+}
+
+// A VarType describes the type of a variable as reported by GET TYPE
+type VarType struct {
+	ReadWrite bool
+	IsEnum    bool
+	IsRange   bool
+	// TypeName holds the data type, as in "NUMBER" or "STRING:64"
+	TypeName string
+}
+
+// Return the raw type tokens of a variable of current ups, as in "RW ENUM"
+func (c *Client) GetVarType(variable string) (string, error) {
+	if len([]rune(c.upsName)) == 0 {
+		return "", errors.New("No UPS defined, use LOGIN first")
+	}
+
+	if len(variable) == 0 {
+		return "", errors.New("Variable cannot be empty")
+	}
+
+	response, err := c.getresponse("TYPE "+c.upsName+" "+variable, "TYPE")
+	if err != nil {
+		return "", err
+	}
+
+	argsstr := strings.Fields(response)
+	if len(argsstr) < 4 {
+		return "", errors.New("Cannot identify ups response")
+	}
+	return strings.Join(argsstr[3:], " "), nil
+}
+
+// Return the type of a variable of current ups
+func (c *Client) GetVarTypeInfo(variable string) (VarType, error) {
+	var vartype VarType
+
+	result, err := c.GetVarType(variable)
+	if err != nil {
+		return vartype, err
+	}
+
+	for _, token := range strings.Fields(result) {
+		switch strings.ToUpper(token) {
+		case "RW":
+			vartype.ReadWrite = true
+		case "ENUM":
+			vartype.IsEnum = true
+		case "RANGE":
+			vartype.IsRange = true
+		default:
+			vartype.TypeName = token
+		}
+	}
+	return vartype, nil
+}
+
+// Return the description of a variable of current ups
+func (c *Client) GetVarDesc(variable string) (string, error) {
+	if len([]rune(c.upsName)) == 0 {
+		return "", errors.New("No UPS defined, use LOGIN first")
+	}
+
+	if len(variable) == 0 {
+		return "", errors.New("Variable cannot be empty")
+	}
+
+	response, err := c.getresponse("DESC "+c.upsName+" "+variable, "DESC")
+	if err != nil {
+		return "", err
+	}
+	return quotedvalue(response), nil
+}
+
+// Return the description of an instant command of current ups
+func (c *Client) GetCmdDesc(command string) (string, error) {
+	if len([]rune(c.upsName)) == 0 {
+		return "", errors.New("No UPS defined, use LOGIN first")
+	}
+
+	if len(command) == 0 {
+		return "", errors.New("Command cannot be empty")
+	}
+
+	response, err := c.getresponse("CMDDESC "+c.upsName+" "+command, "CMDDESC")
+	if err != nil {
+		return "", err
+	}
+	return quotedvalue(response), nil
+}
+
+// Return all vars of current ups with their values, in a single LIST VAR round-trip
+func (c *Client) GetUpsVarsWithValues() (map[string]string, error) {
+	return c.GetAllVars()
+}
+
+// An UpsSnapshot is a point-in-time view of commonly queried ups values.
+// Values absent from the server response are left to zero ; Available tells
+// which nut variables were reported.
+type UpsSnapshot struct {
+	Timestamp       time.Time
+	Manufacturer    string
+	Model           string
+	Serial          string
+	Status          UpsStatus
+	BatteryCharge   float64 // percent
+	BatteryRuntime  float64 // seconds
+	BatteryVoltage  float64 // V
+	InputVoltage    float64 // V
+	InputFrequency  float64 // Hz
+	OutputVoltage   float64 // V
+	OutputFrequency float64 // Hz
+	Load            float64 // percent
+	Temperature     float64 // degrees C
+	ApparentPower   float64 // VA
+	RealPower       float64 // W
+	Available       map[string]bool
+}
+
+// Return a snapshot of current ups, populated from a single LIST VAR round-trip
+func (c *Client) GetUpsSnapshot() (UpsSnapshot, error) {
+	snapshot := UpsSnapshot{Available: make(map[string]bool)}
+
+	vars, err := c.GetAllVars()
+	if err != nil {
+		return snapshot, err
+	}
+	snapshot.Timestamp = time.Now()
+
+	for name := range vars {
+		snapshot.Available[name] = true
+	}
+
+	snapshot.Manufacturer = vars["ups.mfr"]
+	snapshot.Model = vars["ups.model"]
+	snapshot.Serial = vars["ups.serial"]
+	if status, ok := vars["ups.status"]; ok {
+		snapshot.Status = *ParseUpsStatus(status)
+	}
+
+	numbers := map[string]*float64{
+		"battery.charge":   &snapshot.BatteryCharge,
+		"battery.runtime":  &snapshot.BatteryRuntime,
+		"battery.voltage":  &snapshot.BatteryVoltage,
+		"input.voltage":    &snapshot.InputVoltage,
+		"input.frequency":  &snapshot.InputFrequency,
+		"output.voltage":   &snapshot.OutputVoltage,
+		"output.frequency": &snapshot.OutputFrequency,
+		"ups.load":         &snapshot.Load,
+		"ups.temperature":  &snapshot.Temperature,
+		"ups.power":        &snapshot.ApparentPower,
+		"ups.realpower":    &snapshot.RealPower,
+	}
+	for name, field := range numbers {
+		if value := floatvar(vars, name); value != nil {
+			*field = *value
+		}
+	}
+	return snapshot, nil
+}
+
+// ForcedShutdown sends FSD for current ups, telling the nut master that the
+// ups must be shut down now.
+//
+// WARNING: this is a safety-critical command. Once FSD is set, upsmon on the
+// master and on every attached system starts shutting down, and the ups cuts
+// power as soon as the master has finished, whatever the battery charge.
+// Systems attached to the ups that are not monitoring it lose power without
+// warning, which can cause data loss. The client must be authenticated with an
+// account allowed to set FSD (upsmon primary). After a successful call the
+// caller is expected to call Logout and close the connection.
+func (c *Client) ForcedShutdown() error {
+	if len([]rune(c.upsName)) == 0 {
+		return errors.New("No UPS defined, use LOGIN first")
+	}
+
+	result, err := c.cmd("FSD " + c.upsName)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(result), "FSD-SET") {
+		return errors.New("Unexpected FSD response " + result)
+	}
+	return nil
+}
+
+// Return the number of clients logged in to the given ups. No prior Login is required.
+func (c *Client) GetNumLogins(upsName string) (int, error) {
+	if len([]rune(upsName)) == 0 {
+		return -1, errors.New("UPS name cannot be empty")
+	}
+
+	response, err := c.getresponse("NUMLOGINS "+upsName, "NUMLOGINS")
+	if err != nil {
+		return -1, err
+	}
+
+	argsstr := strings.Fields(response)
+	if len(argsstr) < 3 {
+		return -1, errors.New("Cannot identify ups response")
+	}
+
+	value, err := strconv.Atoi(argsstr[2])
+	if err != nil {
+		return -1, fmt.Errorf("Cannot convert number of logins to numerical value: %w", err)
+	}
+	return value, nil
+}
+
+// Return addresses of the clients connected to the given ups
+func (c *Client) GetClientList(upsName string) ([]string, error) {
+	var retslice []string
+
+	if len([]rune(upsName)) == 0 {
+		return nil, errors.New("UPS name cannot be empty")
+	}
+
+	result, err := c.getmultilinesdata("LIST CLIENT " + upsName)
+
+	if err != nil {
+		return nil, fmt.Errorf("Error getting ups clients: %w", err)
+	}
+
+	for _, value := range result {
+		retcode, _, _ := strings.Cut(value, " ")
+
+		if strings.EqualFold(retcode, "CLIENT") {
+			argsstr := strings.Fields(value)
+			if len(argsstr) > 2 {
+				retslice = append(retslice, argsstr[2])
+			}
+		}
+	}
+	return retslice, nil
+}
+
+// Return the server application version string, as sent by VER
+func (c *Client) GetServerVer() (string, error) {
+	return c.rawcmd("VER")
+}
+
+// Return the network protocol version of the server, as sent by NETVER
+func (c *Client) GetNetworkProtocolVersion() (string, error) {
+	result, err := c.rawcmd("NETVER")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(result), nil
+}
+
+// Return the commands understood by the server, as sent by HELP
+func (c *Client) GetServerHelp() ([]string, error) {
+	result, err := c.rawcmd("HELP")
+	if err != nil {
+		return nil, err
+	}
+
+	// The list is introduced by a "Commands:" label
+	argsstr := strings.Fields(result)
+	if (len(argsstr) > 0) && strings.HasSuffix(argsstr[0], ":") {
+		argsstr = argsstr[1:]
+	}
+	return argsstr, nil
+}
+
+//go:generate go run ./internal/safegen
+
+// A SafeClient serializes access to a Client so that it can be shared between goroutines.
+// Every exported method of Client is available on SafeClient and runs while holding
+// the lock ; use Do to run several methods as one exclusive sequence.
+type SafeClient struct {
+	mu     sync.Mutex
+	client *Client
+}
+
+// NewSafeClient returns a SafeClient guarding c. c must not be used directly afterwards.
+func NewSafeClient(c *Client) *SafeClient {
+	return &SafeClient{client: c}
+}
+
+// Do runs fn with exclusive access to the client, as in
+//
+//	err := s.Do(func(c *nutclient.Client) error {
+//		charge, err = c.BatteryCharge()
+//		return err
+//	})
+func (s *SafeClient) Do(fn func(c *Client) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.client)
+}
+
+// Close closes the guarded client connection
+func (s *SafeClient) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.Close()
+}
+
+// Return Battery runtime as a time.Duration
+func (c *Client) BatteryRuntimeDuration() (time.Duration, error) {
+	runtime, err := c.BatteryRuntime()
+	if err != nil {
+		return -1, err
+	}
+	return time.Duration(runtime) * time.Second, nil
+}
+
+// Return Battery runtime low as a time.Duration
+func (c *Client) BatteryRuntimeLowDuration() (time.Duration, error) {
+	runtime, err := c.BatteryRuntimeLow()
+	if err != nil {
+		return -1, err
+	}
+	return time.Duration(runtime) * time.Second, nil
+}
+
+// Return Battery runtime restart as a time.Duration
+func (c *Client) BatteryRuntimeRestartDuration() (time.Duration, error) {
+	runtime, err := c.BatteryRuntimeRestart()
+	if err != nil {
+		return -1, err
+	}
+	return time.Duration(runtime) * time.Second, nil
+}
+
+// Return Battery voltage (V)
+func (c *Client) BatteryVoltage() (float64, error) {
+	return c.getfloatdata("battery.voltage", "battery voltage")
+}
+
+// Return Battery nominal voltage (V)
+func (c *Client) BatteryVoltageNominal() (float64, error) {
+	return c.getfloatdata("battery.voltage.nominal", "battery voltage nominal")
+}
+
+// Return Battery low voltage (V)
+func (c *Client) BatteryVoltageLow() (float64, error) {
+	return c.getfloatdata("battery.voltage.low", "battery voltage low")
+}
+
+// Return Battery high voltage (V)
+func (c *Client) BatteryVoltageHigh() (float64, error) {
+	return c.getfloatdata("battery.voltage.high", "battery voltage high")
+}
+
+// Return Battery current (A)
+func (c *Client) BatteryCurrent() (float64, error) {
+	return c.getfloatdata("battery.current", "battery current")
+}
+
+// Return Battery temperature (degrees C)
+func (c *Client) BatteryTemperature() (float64, error) {
+	temperature, err := c.getfloatdata("battery.temperature", "battery temperature")
+	if errors.Is(err, ErrUnknownVariable) {
+		return -1, fmt.Errorf("Battery temperature not reported by ups: %w", err)
+	}
+	return temperature, err
+}
+
+// Return Output Voltage (V) without truncation
+func (c *Client) OutputVoltageFloat() (float64, error) {
+	return c.getfloatdata("output.voltage", "output voltage")
+}
+
+// Return Input Current (A) without truncation, same as InputCurrentMeasured
+func (c *Client) InputCurrentFloat() (float64, error) {
+	return c.InputCurrentMeasured()
+}
+
+// Return Output Current (A) without truncation
+func (c *Client) OutputCurrentFloat() (float64, error) {
+	return c.getfloatdata("output.current", "output current")
+}
+
+// Return Input Frequency (Hz) without truncation
+func (c *Client) InputFrequencyFloat() (float64, error) {
+	return c.getfloatdata("input.frequency", "input frequency")
+}
+
+// Return Output Frequency (Hz) without truncation
+func (c *Client) OutputFrequencyFloat() (float64, error) {
+	return c.getfloatdata("output.frequency", "output frequency")
+}
+
+// Return minimum input voltage seen since last reset (V), same as InputVoltageMinimum
+func (c *Client) InputVoltageMin() (float64, error) {
+	return c.InputVoltageMinimum()
+}
+
+// Return maximum input voltage seen since last reset (V), same as InputVoltageMaximum
+func (c *Client) InputVoltageMax() (float64, error) {
+	return c.InputVoltageMaximum()
+}
+
+// Return Input nominal voltage (V)
+func (c *Client) InputVoltageNominal() (float64, error) {
+	return c.getfloatdata("input.voltage.nominal", "input voltage nominal")
+}
+
+// Return Output nominal voltage (V)
+func (c *Client) OutputVoltageNominal() (float64, error) {
+	return c.getfloatdata("output.voltage.nominal", "output voltage nominal")
+}
+
+// Return input voltage above which the ups switches to battery (V)
+func (c *Client) InputTransferHigh() (float64, error) {
+	return c.getfloatdata("input.transfer.high", "input transfer high")
+}
+
+// Return input voltage below which the ups switches to battery (V)
+func (c *Client) InputTransferLow() (float64, error) {
+	return c.getfloatdata("input.transfer.low", "input transfer low")
+}
+
+// Set input voltage above which the ups switches to battery (V)
+func (c *Client) SetInputTransferHigh(v float64) error {
+	return c.SetVar("input.transfer.high", strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+// Set input voltage below which the ups switches to battery (V)
+func (c *Client) SetInputTransferLow(v float64) error {
+	return c.SetVar("input.transfer.low", strconv.FormatFloat(v, 'f', -1, 64))
+}
+
+// Return ups power factor (real power / apparent power, between 0 and 1)
+func (c *Client) UpsPowerFactor() (float64, error) {
+	return c.getfloatdata("ups.power.factor", "ups power factor")
+}
+
+// Return ups efficiency (percent of input power delivered to the load)
+func (c *Client) UpsEfficiency() (float64, error) {
+	return c.getfloatdata("ups.efficiency", "ups efficiency")
+}
+
+// Return ups temperature (degrees C) as a float
+func (c *Client) UpsTemperatureFloat() (float64, error) {
+	return c.getfloatdata("ups.temperature", "ups temperature")
+}
+
+// Return Ups manufacturer
+func (c *Client) GetUpsManufacturer() (string, error) {
+	return c.getstringdata("ups.mfr", "ups manufacturer")
+}
+
+// Return Ups firmware version
+func (c *Client) GetUpsFirmwareVersion() (string, error) {
+	return c.getstringdata("ups.firmware", "ups firmware version")
+}
+
+// Return Ups auxiliary firmware version, for models reporting a secondary firmware component
+func (c *Client) GetUpsFirmwareAux() (string, error) {
+	return c.getstringdata("ups.firmware.aux", "ups auxiliary firmware version")
+}
+
+// Return Ups identifier, as set by the administrator
+func (c *Client) GetUpsId() (string, error) {
+	return c.getstringdata("ups.id", "ups id")
+}
+
+// Return Ups location
+func (c *Client) GetUpsLocation() (string, error) {
+	return c.getstringdata("ups.location", "ups location")
+}
+
+// Return Ups contact
+func (c *Client) GetUpsContact() (string, error) {
+	return c.getstringdata("ups.contact", "ups contact")
+}
+
+// Return Ups manufacturing date, as reported by the driver
+func (c *Client) GetUpsMfrDate() (string, error) {
+	return c.getstringdata("ups.mfr.date", "ups manufacturing date")
+}
+
+// Return Ups manufacturing date parsed as a time.Time
+func (c *Client) GetUpsMfrDateParsed() (time.Time, error) {
+	result, err := c.GetUpsMfrDate()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parsedate(result)
+}
+
+// Return the description of a ups, as set in ups.conf on the server
+func (c *Client) GetUpsDescription(upsName string) (string, error) {
+	if len([]rune(upsName)) == 0 {
+		return "", errors.New("UPS name cannot be empty")
+	}
+
+	response, err := c.getresponse("UPSDESC "+upsName, "UPSDESC")
+	if err != nil {
+		return "", err
+	}
+	return quotedvalue(response), nil
+}
+
+// Perform Login command on the only ups declared on the server.
+// An error is returned when the server has no ups or more than one.
+func (c *Client) LoginSingle() error {
+	// GetServerUpsList reports an empty list as an error, read it here instead
+	result, err := c.getmultilinesdata("LIST UPS")
+	if err != nil {
+		return fmt.Errorf("Error getting ups list: %w", err)
+	}
+
+	var list []string
+	for _, value := range result {
+		argsstr := strings.Fields(value)
+		if len(argsstr) > 1 && strings.EqualFold(argsstr[0], "UPS") {
+			list = append(list, argsstr[1])
+		}
+	}
+
+	switch len(list) {
+	case 0:
+		return errors.New("No UPS declared on server")
+	case 1:
+		return c.Login(list[0])
+	default:
+		return errors.New("More than one UPS declared on server, use LOGIN with an ups name")
+	}
+}
+
+// Return Driver name
+func (c *Client) GetDriverName() (string, error) {
+	return c.getstringdata("driver.name", "driver name")
+}
+
+// Return Driver version
+func (c *Client) GetDriverVersion() (string, error) {
+	return c.getstringdata("driver.version", "driver version")
+}
+
+// Return ups declared on the server with their description, same as GetServerUpsEntries
+func (c *Client) GetServerUpsListWithDesc() ([]UpsEntry, error) {
+	return c.GetServerUpsEntries()
+}
+
+// Return ups load (percent) as a float
+func (c *Client) UpsLoadFloat() (float64, error) {
+	return c.getfloatdata("ups.load", "ups load")
+}
+
+// Return current apparent ups power (VA) as a float
+func (c *Client) UpsApparentPowerFloat() (float64, error) {
+	return c.getfloatdata("ups.power", "ups apparent power")
+}
+
+// Return current active ups power (W) as a float
+func (c *Client) UpsActivePowerFloat() (float64, error) {
+	return c.getfloatdata("ups.realpower", "ups active power")
+}
@@ -0,0 +1,195 @@
+// Package nuttest provides a scripted nut server for testing code using nutclient
+package nuttest
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/clamy54/nutclient"
+)
+
+// A TestServer is a local nut server answering commands with scripted responses
+type TestServer struct {
+	// Addr is the address the server listens on
+	Addr      string
+	t         testing.TB
+	listener  net.Listener
+	mutex     sync.Mutex
+	responses map[string]string
+	delays    map[string]time.Duration
+	conns     map[net.Conn]struct{}
+	closed    bool
+	done      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewTestServer starts a TestServer on a random local port and returns it
+// with a client connected to it. Both are closed when the test ends.
+func NewTestServer(t testing.TB) (*TestServer, *nutclient.Client) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("nuttest: cannot listen: %v", err)
+	}
+
+	s := &TestServer{
+		Addr:      listener.Addr().String(),
+		t:         t,
+		listener:  listener,
+		responses: make(map[string]string),
+		delays:    make(map[string]time.Duration),
+		conns:     make(map[net.Conn]struct{}),
+		done:      make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.serve()
+
+	t.Cleanup(func() {
+		s.Close()
+	})
+	return s, s.Dial()
+}
+
+// Dial returns a new client connected to the server and configured with opts.
+// It is closed when the test ends.
+func (s *TestServer) Dial(opts ...nutclient.ClientOption) *nutclient.Client {
+	s.t.Helper()
+
+	c, err := nutclient.DialWithOptions(s.Addr, opts...)
+	if err != nil {
+		s.t.Fatalf("nuttest: cannot connect to test server: %v", err)
+	}
+	s.t.Cleanup(func() {
+		c.Close()
+	})
+	return c
+}
+
+// ExpectCommand makes the server answer cmd with response.
+// Multiline responses, such as LIST results, are given with lines separated by "\n".
+// A command can be expected several times, the last response set is used.
+func (s *TestServer) ExpectCommand(cmd string, response string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.responses[strings.TrimSpace(cmd)] = response
+	delete(s.delays, strings.TrimSpace(cmd))
+}
+
+// ExpectCommandDelayed is like ExpectCommand, with the server waiting delay
+// before answering, as a slow driver would. Later commands sent on the same
+// connection are answered after this one.
+func (s *TestServer) ExpectCommandDelayed(cmd string, response string, delay time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.responses[strings.TrimSpace(cmd)] = response
+	s.delays[strings.TrimSpace(cmd)] = delay
+}
+
+// ExpectVar makes the server answer GET VAR of variable on upsName with value
+func (s *TestServer) ExpectVar(upsName string, variable string, value string) {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "\"", "\\\"")
+	s.ExpectCommand("GET VAR "+upsName+" "+variable, "VAR "+upsName+" "+variable+" \""+value+"\"")
+}
+
+// CloseConnections closes all client connections while the server keeps
+// accepting new ones, as a restarted nut server would
+func (s *TestServer) CloseConnections() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+}
+
+// Close stops the server and closes all client connections
+func (s *TestServer) Close() error {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mutex.Unlock()
+
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+// Accept connections until the listener is closed
+func (s *TestServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mutex.Lock()
+		if s.closed {
+			s.mutex.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mutex.Unlock()
+
+		go s.handle(conn)
+	}
+}
+
+// Answer commands sent on conn until it is closed
+func (s *TestServer) handle(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mutex.Lock()
+		delete(s.conns, conn)
+		s.mutex.Unlock()
+		conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		command := strings.TrimSpace(scanner.Text())
+		if len(command) == 0 {
+			continue
+		}
+
+		s.mutex.Lock()
+		response, ok := s.responses[command]
+		delay := s.delays[command]
+		s.mutex.Unlock()
+
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-s.done:
+				return
+			}
+		}
+
+		if !ok {
+			s.t.Errorf("nuttest: unexpected command %q", command)
+			response = "ERR UNKNOWN-COMMAND"
+		}
+
+		for _, line := range strings.Split(response, "\n") {
+			if _, err := fmt.Fprintf(conn, "%s\n", line); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package nuttest_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/clamy54/nutclient"
+	"github.com/clamy54/nutclient/nuttest"
+)
+
+func TestErrorResponses(t *testing.T) {
+	s, c := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectCommand("LOGIN nope", "ERR UNKNOWN-UPS")
+	s.ExpectCommand("GET VAR ups1 foo.bar", "ERR VAR-NOT-SUPPORTED")
+	s.ExpectCommand("INSTCMD ups1 beeper.toggle", "ERR ACCESS-DENIED")
+	s.ExpectCommand("LIST CMD ups1", "ERR DATA-STALE")
+
+	err := c.Login("nope")
+	var nutErr *nutclient.NutError
+	if !errors.As(err, &nutErr) {
+		t.Fatalf("Login error %v is not a NutError", err)
+	}
+	if nutErr.Code != "UNKNOWN-UPS" || nutErr.Message != "ERR UNKNOWN-UPS" {
+		t.Errorf("got NutError{%q, %q}", nutErr.Code, nutErr.Message)
+	}
+	if !errors.Is(err, nutclient.ErrUnknownUPS) {
+		t.Errorf("Login error %v does not match ErrUnknownUPS", err)
+	}
+
+	if err := c.Login("ups1"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.GetData("foo.bar")
+	if !errors.Is(err, nutclient.ErrVarNotSupported) || !errors.Is(err, nutclient.ErrUnknownVariable) {
+		t.Errorf("GetData error %v does not match ErrVarNotSupported", err)
+	}
+
+	err = c.RunInstantCommand("beeper.toggle")
+	if !errors.Is(err, nutclient.ErrAccessDenied) {
+		t.Errorf("RunInstantCommand error %v does not match ErrAccessDenied", err)
+	}
+
+	// Errors wrapped by higher level getters keep the NUT error
+	_, err = c.ListCommands()
+	if !errors.Is(err, nutclient.ErrDataStale) {
+		t.Errorf("ListCommands error %v does not match ErrDataStale", err)
+	}
+	if errors.Is(err, nutclient.ErrAccessDenied) {
+		t.Errorf("ListCommands error %v matches ErrAccessDenied", err)
+	}
+}
+
+func TestGetServerUpsStatusSummary(t *testing.T) {
+	s, c := nuttest.NewTestServer(t)
+	s.ExpectCommand("LIST UPS", "BEGIN LIST UPS\nUPS ups1 \"Main\"\nUPS ups2 \"Backup\"\nUPS ups3 \"Broken\"\nEND LIST UPS")
+	s.ExpectCommand("LIST VAR ups1", "BEGIN LIST VAR ups1\nVAR ups1 ups.status \"OL CHRG\"\nEND LIST VAR ups1")
+	s.ExpectCommand("LIST VAR ups2", "BEGIN LIST VAR ups2\nVAR ups2 ups.status \"OB LB\"\nEND LIST VAR ups2")
+	s.ExpectCommand("LIST VAR ups3", "ERR DRIVER-NOT-CONNECTED")
+
+	summary, err := c.GetServerUpsStatusSummary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary) != 3 {
+		t.Fatalf("got %d entries, want 3", len(summary))
+	}
+
+	if status := summary["ups1"]; status.Err != nil || !status.Online || !status.Charging || status.OnBattery {
+		t.Errorf("ups1: got %+v", status)
+	}
+	if status := summary["ups2"]; status.Err != nil || !status.OnBattery || !status.LowBattery || status.Online {
+		t.Errorf("ups2: got %+v", status)
+	}
+	if status := summary["ups3"]; !errors.Is(status.Err, nutclient.ErrDriverNotConnected) {
+		t.Errorf("ups3: got error %v, want ErrDriverNotConnected", status.Err)
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	s, _ := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectCommand("LOGOUT", "OK Goodbye")
+
+	c := s.Dial(nutclient.WithIdleTimeout(50 * time.Millisecond))
+	if err := c.Login("ups1"); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if err := c.Login("ups1"); !errors.Is(err, nutclient.ErrConnectionClosed) {
+		t.Errorf("got %v after idle timeout, want ErrConnectionClosed", err)
+	}
+}
+
+func TestAutoReconnect(t *testing.T) {
+	s, _ := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectVar("ups1", "battery.charge", "87")
+
+	c := s.Dial(nutclient.WithAutoReconnect(nutclient.RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond}))
+	if err := c.Login("ups1"); err != nil {
+		t.Fatal(err)
+	}
+
+	s.CloseConnections()
+
+	// The call hitting the dropped connection reports the error,
+	// then the client dials again and logs in to ups1
+	if _, err := c.BatteryCharge(); err == nil {
+		t.Error("got no error on the dropped connection")
+	}
+
+	charge, err := c.BatteryCharge()
+	if err != nil {
+		t.Fatalf("after reconnection: %v", err)
+	}
+	if charge != 87 {
+		t.Errorf("got charge %d, want 87", charge)
+	}
+}
+
+func TestReadTimeoutClosesConnection(t *testing.T) {
+	s, _ := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectCommandDelayed("GET VAR ups1 battery.charge", "VAR ups1 battery.charge \"87\"", 100*time.Millisecond)
+	s.ExpectVar("ups1", "ups.load", "23")
+
+	c := s.Dial(nutclient.WithReadTimeout(50 * time.Millisecond))
+	if err := c.Login("ups1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetData("battery.charge"); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("got %v, want a timeout", err)
+	}
+
+	// The late battery.charge response must not be read as the ups.load one
+	if value, err := c.GetData("ups.load"); !errors.Is(err, nutclient.ErrConnectionClosed) {
+		t.Errorf("got %q, %v after a timeout, want ErrConnectionClosed", value, err)
+	}
+}
+
+func TestReadTimeoutReconnects(t *testing.T) {
+	s, _ := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectCommandDelayed("GET VAR ups1 battery.charge", "VAR ups1 battery.charge \"87\"", 100*time.Millisecond)
+	s.ExpectVar("ups1", "ups.load", "23")
+
+	c := s.Dial(nutclient.WithReadTimeout(50*time.Millisecond),
+		nutclient.WithAutoReconnect(nutclient.RetryPolicy{MaxAttempts: 3, BaseDelay: 10 * time.Millisecond}))
+	if err := c.Login("ups1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.GetData("battery.charge"); err == nil {
+		t.Fatal("got no error on the delayed response")
+	}
+
+	value, err := c.GetData("ups.load")
+	if err != nil {
+		t.Fatalf("after reconnection: %v", err)
+	}
+	if value != "23" {
+		t.Errorf("got ups.load %q, want \"23\"", value)
+	}
+}
+
+func TestGetDataChecksVariable(t *testing.T) {
+	s, c := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectCommand("GET VAR ups1 ups.load", "VAR ups1 battery.charge \"87\"")
+
+	if err := c.Login("ups1"); err != nil {
+		t.Fatal(err)
+	}
+	if value, err := c.GetData("ups.load"); err == nil {
+		t.Errorf("got %q for a response about another variable", value)
+	}
+}
+
+func TestContextCancelClosesConnection(t *testing.T) {
+	s, c := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectCommandDelayed("GET VAR ups1 battery.charge", "VAR ups1 battery.charge \"87\"", 200*time.Millisecond)
+	s.ExpectVar("ups1", "ups.load", "23")
+
+	if err := c.Login("ups1"); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.SetContext(ctx)
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if _, err := c.GetData("battery.charge"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+
+	c.SetContext(context.Background())
+	if value, err := c.GetData("ups.load"); !errors.Is(err, nutclient.ErrConnectionClosed) {
+		t.Errorf("got %q, %v after a cancelled command, want ErrConnectionClosed", value, err)
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	s, _ := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectCommandDelayed("GET VAR ups1 battery.charge", "VAR ups1 battery.charge \"87\"", 200*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	c := s.Dial(nutclient.WithContext(ctx))
+	if err := c.Login("ups1"); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if _, err := c.GetData("battery.charge"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed >= 190*time.Millisecond {
+		t.Errorf("command returned after %v, past the context deadline", elapsed)
+	}
+}
+
+func TestSetReadDeadline(t *testing.T) {
+	s, c := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectVar("ups1", "ups.load", "23")
+
+	if err := c.Login("ups1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.SetReadDeadline(time.Now().Add(100 * time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetData("ups.load"); err != nil {
+		t.Fatalf("before the deadline: %v", err)
+	}
+
+	// The deadline set by the caller is kept across commands
+	time.Sleep(150 * time.Millisecond)
+	if _, err := c.GetData("ups.load"); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("got %v after the deadline, want a timeout", err)
+	}
+
+	c.SetReadDeadline(time.Time{})
+	if _, err := c.GetData("ups.load"); !errors.Is(err, nutclient.ErrConnectionClosed) {
+		t.Errorf("got %v after a timeout, want ErrConnectionClosed", err)
+	}
+}
+
+func TestSetOutputVoltageTargetWaitsForDriver(t *testing.T) {
+	s, c := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectCommand("LIST RANGE ups1 output.voltage.target", "BEGIN LIST RANGE ups1 output.voltage.target\nRANGE ups1 output.voltage.target \"220\" \"240\"\nEND LIST RANGE ups1 output.voltage.target")
+	s.ExpectCommand("LIST RW ups1", "BEGIN LIST RW ups1\nRW ups1 output.voltage.target \"230\"\nEND LIST RW ups1")
+	s.ExpectCommand("SET VAR ups1 output.voltage.target \"220\"", "OK")
+	s.ExpectVar("ups1", "output.voltage.target", "230")
+
+	if err := c.Login("ups1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// upsd answers OK before the driver applies the new value
+	time.AfterFunc(300*time.Millisecond, func() {
+		s.ExpectVar("ups1", "output.voltage.target", "220")
+	})
+
+	if err := c.SetOutputVoltageTarget(220); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetOutputVoltageTarget(250); !errors.Is(err, nutclient.ErrValueOutOfRange) {
+		t.Errorf("got %v for a value out of range, want ErrValueOutOfRange", err)
+	}
+}
+
+func TestDialContextWithOptions(t *testing.T) {
+	s, _ := nuttest.NewTestServer(t)
+	s.ExpectCommand("LOGIN ups1", "OK")
+	s.ExpectCommandDelayed("GET VAR ups1 battery.charge", "VAR ups1 battery.charge \"87\"", 100*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	c, err := nutclient.DialContext(ctx, s.Addr, nutclient.WithReadTimeout(50*time.Millisecond))
+	cancel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// The dial context does not bound further commands
+	if err := c.Login("ups1"); err != nil {
+		t.Fatalf("after cancelling the dial context: %v", err)
+	}
+	if _, err := c.GetData("battery.charge"); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("got %v, want the read timeout set by the options", err)
+	}
+}
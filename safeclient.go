@@ -0,0 +1,1689 @@
+// Code generated by internal/safegen; DO NOT EDIT.
+
+package nutclient
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+)
+
+// Disconnect calls Client.Disconnect while holding the lock
+func (s *SafeClient) Disconnect() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.Disconnect()
+}
+
+// SetContext calls Client.SetContext while holding the lock
+func (s *SafeClient) SetContext(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.client.SetContext(ctx)
+}
+
+// SetDeadline calls Client.SetDeadline while holding the lock
+func (s *SafeClient) SetDeadline(t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetDeadline(t)
+}
+
+// SetReadDeadline calls Client.SetReadDeadline while holding the lock
+func (s *SafeClient) SetReadDeadline(t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetReadDeadline(t)
+}
+
+// SetWriteDeadline calls Client.SetWriteDeadline while holding the lock
+func (s *SafeClient) SetWriteDeadline(t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetWriteDeadline(t)
+}
+
+// GetData calls Client.GetData while holding the lock
+func (s *SafeClient) GetData(format string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetData(format)
+}
+
+// GetDataFloat calls Client.GetDataFloat while holding the lock
+func (s *SafeClient) GetDataFloat(variable string) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetDataFloat(variable)
+}
+
+// StartTLS calls Client.StartTLS while holding the lock
+func (s *SafeClient) StartTLS(configtls *tls.Config) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.StartTLS(configtls)
+}
+
+// Auth calls Client.Auth while holding the lock
+func (s *SafeClient) Auth(login string, password string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.Auth(login, password)
+}
+
+// Login calls Client.Login while holding the lock
+func (s *SafeClient) Login(upsName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.Login(upsName)
+}
+
+// Logout calls Client.Logout while holding the lock
+func (s *SafeClient) Logout() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.Logout()
+}
+
+// GetUpsStatus calls Client.GetUpsStatus while holding the lock
+func (s *SafeClient) GetUpsStatus() (UpsStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsStatus()
+}
+
+// IsOnline calls Client.IsOnline while holding the lock
+func (s *SafeClient) IsOnline() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.IsOnline()
+}
+
+// IsOnBattery calls Client.IsOnBattery while holding the lock
+func (s *SafeClient) IsOnBattery() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.IsOnBattery()
+}
+
+// IsLowBattery calls Client.IsLowBattery while holding the lock
+func (s *SafeClient) IsLowBattery() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.IsLowBattery()
+}
+
+// BatteryCharge calls Client.BatteryCharge while holding the lock
+func (s *SafeClient) BatteryCharge() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryCharge()
+}
+
+// BatteryChargeLow calls Client.BatteryChargeLow while holding the lock
+func (s *SafeClient) BatteryChargeLow() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryChargeLow()
+}
+
+// BatteryChargeWarning calls Client.BatteryChargeWarning while holding the lock
+func (s *SafeClient) BatteryChargeWarning() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryChargeWarning()
+}
+
+// BatteryChargeRestart calls Client.BatteryChargeRestart while holding the lock
+func (s *SafeClient) BatteryChargeRestart() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryChargeRestart()
+}
+
+// BatteryRuntime calls Client.BatteryRuntime while holding the lock
+func (s *SafeClient) BatteryRuntime() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryRuntime()
+}
+
+// BatteryRuntimeLow calls Client.BatteryRuntimeLow while holding the lock
+func (s *SafeClient) BatteryRuntimeLow() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryRuntimeLow()
+}
+
+// BatteryRuntimeRestart calls Client.BatteryRuntimeRestart while holding the lock
+func (s *SafeClient) BatteryRuntimeRestart() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryRuntimeRestart()
+}
+
+// GetServerInfo calls Client.GetServerInfo while holding the lock
+func (s *SafeClient) GetServerInfo() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetServerInfo()
+}
+
+// GetServerVersion calls Client.GetServerVersion while holding the lock
+func (s *SafeClient) GetServerVersion() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetServerVersion()
+}
+
+// GetServerUpsList calls Client.GetServerUpsList while holding the lock
+func (s *SafeClient) GetServerUpsList() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetServerUpsList()
+}
+
+// GetUpsVars calls Client.GetUpsVars while holding the lock
+func (s *SafeClient) GetUpsVars() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsVars()
+}
+
+// UpsLoad calls Client.UpsLoad while holding the lock
+func (s *SafeClient) UpsLoad() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsLoad()
+}
+
+// UpsTemperature calls Client.UpsTemperature while holding the lock
+func (s *SafeClient) UpsTemperature() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsTemperature()
+}
+
+// UpsApparentPower calls Client.UpsApparentPower while holding the lock
+func (s *SafeClient) UpsApparentPower() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsApparentPower()
+}
+
+// UpsActivePower calls Client.UpsActivePower while holding the lock
+func (s *SafeClient) UpsActivePower() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsActivePower()
+}
+
+// InputVoltage calls Client.InputVoltage while holding the lock
+func (s *SafeClient) InputVoltage() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputVoltage()
+}
+
+// InputCurrent calls Client.InputCurrent while holding the lock
+func (s *SafeClient) InputCurrent() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputCurrent()
+}
+
+// OutputVoltage calls Client.OutputVoltage while holding the lock
+func (s *SafeClient) OutputVoltage() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputVoltage()
+}
+
+// OutputCurrent calls Client.OutputCurrent while holding the lock
+func (s *SafeClient) OutputCurrent() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputCurrent()
+}
+
+// OutputFrequency calls Client.OutputFrequency while holding the lock
+func (s *SafeClient) OutputFrequency() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputFrequency()
+}
+
+// InputFrequency calls Client.InputFrequency while holding the lock
+func (s *SafeClient) InputFrequency() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputFrequency()
+}
+
+// GetUpsModel calls Client.GetUpsModel while holding the lock
+func (s *SafeClient) GetUpsModel() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsModel()
+}
+
+// GetUpsSerial calls Client.GetUpsSerial while holding the lock
+func (s *SafeClient) GetUpsSerial() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsSerial()
+}
+
+// GetServerUpsEntries calls Client.GetServerUpsEntries while holding the lock
+func (s *SafeClient) GetServerUpsEntries() ([]UpsEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetServerUpsEntries()
+}
+
+// GetAllVarsForUps calls Client.GetAllVarsForUps while holding the lock
+func (s *SafeClient) GetAllVarsForUps(upsName string) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetAllVarsForUps(upsName)
+}
+
+// GetAllVars calls Client.GetAllVars while holding the lock
+func (s *SafeClient) GetAllVars() (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetAllVars()
+}
+
+// GetServerUpsStatusSummary calls Client.GetServerUpsStatusSummary while holding the lock
+func (s *SafeClient) GetServerUpsStatusSummary() (map[string]*UpsStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetServerUpsStatusSummary()
+}
+
+// GetPowerQualityReport calls Client.GetPowerQualityReport while holding the lock
+func (s *SafeClient) GetPowerQualityReport() (*PowerQualityReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetPowerQualityReport()
+}
+
+// BatteryCurrentDirection calls Client.BatteryCurrentDirection while holding the lock
+func (s *SafeClient) BatteryCurrentDirection() (CurrentDirection, float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryCurrentDirection()
+}
+
+// InputCurrentHarmonic calls Client.InputCurrentHarmonic while holding the lock
+func (s *SafeClient) InputCurrentHarmonic(n int) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputCurrentHarmonic(n)
+}
+
+// ListInputCurrentHarmonics calls Client.ListInputCurrentHarmonics while holding the lock
+func (s *SafeClient) ListInputCurrentHarmonics() (map[int]float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ListInputCurrentHarmonics()
+}
+
+// GetPowerRating calls Client.GetPowerRating while holding the lock
+func (s *SafeClient) GetPowerRating() (*PowerRating, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetPowerRating()
+}
+
+// StatusPrettyString calls Client.StatusPrettyString while holding the lock
+func (s *SafeClient) StatusPrettyString() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.StatusPrettyString()
+}
+
+// GetOutputApparentPowerAny calls Client.GetOutputApparentPowerAny while holding the lock
+func (s *SafeClient) GetOutputApparentPowerAny() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetOutputApparentPowerAny()
+}
+
+// GetOutputRealPowerAny calls Client.GetOutputRealPowerAny while holding the lock
+func (s *SafeClient) GetOutputRealPowerAny() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetOutputRealPowerAny()
+}
+
+// BatteryDate calls Client.BatteryDate while holding the lock
+func (s *SafeClient) BatteryDate() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryDate()
+}
+
+// BatteryDateParsed calls Client.BatteryDateParsed while holding the lock
+func (s *SafeClient) BatteryDateParsed() (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryDateParsed()
+}
+
+// BatteryAgeDays calls Client.BatteryAgeDays while holding the lock
+func (s *SafeClient) BatteryAgeDays() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryAgeDays()
+}
+
+// InputCurrentMaximum calls Client.InputCurrentMaximum while holding the lock
+func (s *SafeClient) InputCurrentMaximum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputCurrentMaximum()
+}
+
+// InputCurrentMinimum calls Client.InputCurrentMinimum while holding the lock
+func (s *SafeClient) InputCurrentMinimum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputCurrentMinimum()
+}
+
+// InputVoltageMaximumSeen calls Client.InputVoltageMaximumSeen while holding the lock
+func (s *SafeClient) InputVoltageMaximumSeen() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputVoltageMaximumSeen()
+}
+
+// InputVoltageMinimumSeen calls Client.InputVoltageMinimumSeen while holding the lock
+func (s *SafeClient) InputVoltageMinimumSeen() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputVoltageMinimumSeen()
+}
+
+// ResetInputMinMax calls Client.ResetInputMinMax while holding the lock
+func (s *SafeClient) ResetInputMinMax() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ResetInputMinMax()
+}
+
+// OutputPowerMaximum calls Client.OutputPowerMaximum while holding the lock
+func (s *SafeClient) OutputPowerMaximum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputPowerMaximum()
+}
+
+// OutputRealPowerMaximum calls Client.OutputRealPowerMaximum while holding the lock
+func (s *SafeClient) OutputRealPowerMaximum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputRealPowerMaximum()
+}
+
+// ResetOutputMinMax calls Client.ResetOutputMinMax while holding the lock
+func (s *SafeClient) ResetOutputMinMax() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ResetOutputMinMax()
+}
+
+// GetInputCapabilityInfo calls Client.GetInputCapabilityInfo while holding the lock
+func (s *SafeClient) GetInputCapabilityInfo() (*InputCapabilityInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetInputCapabilityInfo()
+}
+
+// UpsDate calls Client.UpsDate while holding the lock
+func (s *SafeClient) UpsDate() (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsDate()
+}
+
+// SetUpsTime calls Client.SetUpsTime while holding the lock
+func (s *SafeClient) SetUpsTime(t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetUpsTime(t)
+}
+
+// OutputFrequencySlew calls Client.OutputFrequencySlew while holding the lock
+func (s *SafeClient) OutputFrequencySlew() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputFrequencySlew()
+}
+
+// GetFrequencyDeviation calls Client.GetFrequencyDeviation while holding the lock
+func (s *SafeClient) GetFrequencyDeviation() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetFrequencyDeviation()
+}
+
+// BatteryProtectionActive calls Client.BatteryProtectionActive while holding the lock
+func (s *SafeClient) BatteryProtectionActive() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryProtectionActive()
+}
+
+// BatteryCondition calls Client.BatteryCondition while holding the lock
+func (s *SafeClient) BatteryCondition() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryCondition()
+}
+
+// BatteryConditionGood calls Client.BatteryConditionGood while holding the lock
+func (s *SafeClient) BatteryConditionGood() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryConditionGood()
+}
+
+// GetDriverParameterMap calls Client.GetDriverParameterMap while holding the lock
+func (s *SafeClient) GetDriverParameterMap() (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetDriverParameterMap()
+}
+
+// ShutdownWindowMinutes calls Client.ShutdownWindowMinutes while holding the lock
+func (s *SafeClient) ShutdownWindowMinutes() (float64, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ShutdownWindowMinutes()
+}
+
+// OutputCurrentMaximum calls Client.OutputCurrentMaximum while holding the lock
+func (s *SafeClient) OutputCurrentMaximum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputCurrentMaximum()
+}
+
+// OutputL1CurrentMaximum calls Client.OutputL1CurrentMaximum while holding the lock
+func (s *SafeClient) OutputL1CurrentMaximum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputL1CurrentMaximum()
+}
+
+// OutputL2CurrentMaximum calls Client.OutputL2CurrentMaximum while holding the lock
+func (s *SafeClient) OutputL2CurrentMaximum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputL2CurrentMaximum()
+}
+
+// OutputL3CurrentMaximum calls Client.OutputL3CurrentMaximum while holding the lock
+func (s *SafeClient) OutputL3CurrentMaximum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputL3CurrentMaximum()
+}
+
+// AmbientTemperatureCompensation calls Client.AmbientTemperatureCompensation while holding the lock
+func (s *SafeClient) AmbientTemperatureCompensation() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.AmbientTemperatureCompensation()
+}
+
+// SetAmbientTemperatureCompensation calls Client.SetAmbientTemperatureCompensation while holding the lock
+func (s *SafeClient) SetAmbientTemperatureCompensation(value float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetAmbientTemperatureCompensation(value)
+}
+
+// AmbientHumidityCompensationEnabled calls Client.AmbientHumidityCompensationEnabled while holding the lock
+func (s *SafeClient) AmbientHumidityCompensationEnabled() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.AmbientHumidityCompensationEnabled()
+}
+
+// GetOutletCount calls Client.GetOutletCount while holding the lock
+func (s *SafeClient) GetOutletCount() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetOutletCount()
+}
+
+// OutletApparentPower calls Client.OutletApparentPower while holding the lock
+func (s *SafeClient) OutletApparentPower(n int) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutletApparentPower(n)
+}
+
+// OutletRealPower calls Client.OutletRealPower while holding the lock
+func (s *SafeClient) OutletRealPower(n int) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutletRealPower(n)
+}
+
+// GetAllOutletPower calls Client.GetAllOutletPower while holding the lock
+func (s *SafeClient) GetAllOutletPower() (map[int]float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetAllOutletPower()
+}
+
+// InputCurrentMeasured calls Client.InputCurrentMeasured while holding the lock
+func (s *SafeClient) InputCurrentMeasured() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputCurrentMeasured()
+}
+
+// UpsLoad1Min calls Client.UpsLoad1Min while holding the lock
+func (s *SafeClient) UpsLoad1Min() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsLoad1Min()
+}
+
+// UpsLoad5Min calls Client.UpsLoad5Min while holding the lock
+func (s *SafeClient) UpsLoad5Min() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsLoad5Min()
+}
+
+// UpsLoad15Min calls Client.UpsLoad15Min while holding the lock
+func (s *SafeClient) UpsLoad15Min() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsLoad15Min()
+}
+
+// GetLoadAverages calls Client.GetLoadAverages while holding the lock
+func (s *SafeClient) GetLoadAverages() (*LoadAverages, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetLoadAverages()
+}
+
+// OutputVoltageMinimum calls Client.OutputVoltageMinimum while holding the lock
+func (s *SafeClient) OutputVoltageMinimum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputVoltageMinimum()
+}
+
+// OutputVoltageMaximum calls Client.OutputVoltageMaximum while holding the lock
+func (s *SafeClient) OutputVoltageMaximum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputVoltageMaximum()
+}
+
+// InputVoltageMinimum calls Client.InputVoltageMinimum while holding the lock
+func (s *SafeClient) InputVoltageMinimum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputVoltageMinimum()
+}
+
+// InputVoltageMaximum calls Client.InputVoltageMaximum while holding the lock
+func (s *SafeClient) InputVoltageMaximum() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputVoltageMaximum()
+}
+
+// ChargeMode calls Client.ChargeMode while holding the lock
+func (s *SafeClient) ChargeMode() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ChargeMode()
+}
+
+// IsFloatCharging calls Client.IsFloatCharging while holding the lock
+func (s *SafeClient) IsFloatCharging() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.IsFloatCharging()
+}
+
+// IsBulkCharging calls Client.IsBulkCharging while holding the lock
+func (s *SafeClient) IsBulkCharging() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.IsBulkCharging()
+}
+
+// NetcardIPv6Address calls Client.NetcardIPv6Address while holding the lock
+func (s *SafeClient) NetcardIPv6Address() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.NetcardIPv6Address()
+}
+
+// GetNetworkCardAddresses calls Client.GetNetworkCardAddresses while holding the lock
+func (s *SafeClient) GetNetworkCardAddresses() (*NetworkCardAddresses, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetNetworkCardAddresses()
+}
+
+// BypassSource calls Client.BypassSource while holding the lock
+func (s *SafeClient) BypassSource() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BypassSource()
+}
+
+// BypassVoltage calls Client.BypassVoltage while holding the lock
+func (s *SafeClient) BypassVoltage() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BypassVoltage()
+}
+
+// BypassCurrent calls Client.BypassCurrent while holding the lock
+func (s *SafeClient) BypassCurrent() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BypassCurrent()
+}
+
+// BypassFrequency calls Client.BypassFrequency while holding the lock
+func (s *SafeClient) BypassFrequency() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BypassFrequency()
+}
+
+// GetBypassInfo calls Client.GetBypassInfo while holding the lock
+func (s *SafeClient) GetBypassInfo() (*BypassInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetBypassInfo()
+}
+
+// FuseStatus calls Client.FuseStatus while holding the lock
+func (s *SafeClient) FuseStatus() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.FuseStatus()
+}
+
+// CircuitBreakerStatus calls Client.CircuitBreakerStatus while holding the lock
+func (s *SafeClient) CircuitBreakerStatus() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.CircuitBreakerStatus()
+}
+
+// MaintenanceBypassStatus calls Client.MaintenanceBypassStatus while holding the lock
+func (s *SafeClient) MaintenanceBypassStatus() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.MaintenanceBypassStatus()
+}
+
+// GetMechanicalStatus calls Client.GetMechanicalStatus while holding the lock
+func (s *SafeClient) GetMechanicalStatus() (*MechanicalStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetMechanicalStatus()
+}
+
+// ListEnumValues calls Client.ListEnumValues while holding the lock
+func (s *SafeClient) ListEnumValues(variable string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ListEnumValues(variable)
+}
+
+// DisplayLanguage calls Client.DisplayLanguage while holding the lock
+func (s *SafeClient) DisplayLanguage() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.DisplayLanguage()
+}
+
+// SetDisplayLanguage calls Client.SetDisplayLanguage while holding the lock
+func (s *SafeClient) SetDisplayLanguage(lang string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetDisplayLanguage(lang)
+}
+
+// ListDisplayLanguages calls Client.ListDisplayLanguages while holding the lock
+func (s *SafeClient) ListDisplayLanguages() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ListDisplayLanguages()
+}
+
+// DisplayScrollInterval calls Client.DisplayScrollInterval while holding the lock
+func (s *SafeClient) DisplayScrollInterval() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.DisplayScrollInterval()
+}
+
+// SetDisplayScrollInterval calls Client.SetDisplayScrollInterval while holding the lock
+func (s *SafeClient) SetDisplayScrollInterval(seconds int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetDisplayScrollInterval(seconds)
+}
+
+// NeedsBatteryReplacement calls Client.NeedsBatteryReplacement while holding the lock
+func (s *SafeClient) NeedsBatteryReplacement() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.NeedsBatteryReplacement()
+}
+
+// BatteryReplacementDue calls Client.BatteryReplacementDue while holding the lock
+func (s *SafeClient) BatteryReplacementDue() (*time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryReplacementDue()
+}
+
+// SetBatteryReplacementDate calls Client.SetBatteryReplacementDate while holding the lock
+func (s *SafeClient) SetBatteryReplacementDate(t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetBatteryReplacementDate(t)
+}
+
+// InputPowerFactor calls Client.InputPowerFactor while holding the lock
+func (s *SafeClient) InputPowerFactor() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputPowerFactor()
+}
+
+// OutputPowerFactor calls Client.OutputPowerFactor while holding the lock
+func (s *SafeClient) OutputPowerFactor() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputPowerFactor()
+}
+
+// PowerFactorBalance calls Client.PowerFactorBalance while holding the lock
+func (s *SafeClient) PowerFactorBalance() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.PowerFactorBalance()
+}
+
+// InputFrequencyDetected calls Client.InputFrequencyDetected while holding the lock
+func (s *SafeClient) InputFrequencyDetected() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputFrequencyDetected()
+}
+
+// IsGlobalCompatible calls Client.IsGlobalCompatible while holding the lock
+func (s *SafeClient) IsGlobalCompatible() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.IsGlobalCompatible()
+}
+
+// NTPServer calls Client.NTPServer while holding the lock
+func (s *SafeClient) NTPServer() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.NTPServer()
+}
+
+// SetNTPServer calls Client.SetNTPServer while holding the lock
+func (s *SafeClient) SetNTPServer(addr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetNTPServer(addr)
+}
+
+// NTPSyncEnabled calls Client.NTPSyncEnabled while holding the lock
+func (s *SafeClient) NTPSyncEnabled() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.NTPSyncEnabled()
+}
+
+// StandbyMode calls Client.StandbyMode while holding the lock
+func (s *SafeClient) StandbyMode() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.StandbyMode()
+}
+
+// SetStandbyMode calls Client.SetStandbyMode while holding the lock
+func (s *SafeClient) SetStandbyMode(mode string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetStandbyMode(mode)
+}
+
+// EcoModeEnabled calls Client.EcoModeEnabled while holding the lock
+func (s *SafeClient) EcoModeEnabled() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.EcoModeEnabled()
+}
+
+// OutputVoltageDC calls Client.OutputVoltageDC while holding the lock
+func (s *SafeClient) OutputVoltageDC() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputVoltageDC()
+}
+
+// OutputCurrentDC calls Client.OutputCurrentDC while holding the lock
+func (s *SafeClient) OutputCurrentDC() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputCurrentDC()
+}
+
+// BatteryVoltageDC calls Client.BatteryVoltageDC while holding the lock
+func (s *SafeClient) BatteryVoltageDC() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryVoltageDC()
+}
+
+// UpsHealth calls Client.UpsHealth while holding the lock
+func (s *SafeClient) UpsHealth() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsHealth()
+}
+
+// UpsHealthSummary calls Client.UpsHealthSummary while holding the lock
+func (s *SafeClient) UpsHealthSummary() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsHealthSummary()
+}
+
+// BatteryHealthStatus calls Client.BatteryHealthStatus while holding the lock
+func (s *SafeClient) BatteryHealthStatus() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryHealthStatus()
+}
+
+// ServiceRequest calls Client.ServiceRequest while holding the lock
+func (s *SafeClient) ServiceRequest() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ServiceRequest()
+}
+
+// NeedsService calls Client.NeedsService while holding the lock
+func (s *SafeClient) NeedsService() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.NeedsService()
+}
+
+// OutletAutoEnableDelay calls Client.OutletAutoEnableDelay while holding the lock
+func (s *SafeClient) OutletAutoEnableDelay(n int) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutletAutoEnableDelay(n)
+}
+
+// SetOutletAutoEnableDelay calls Client.SetOutletAutoEnableDelay while holding the lock
+func (s *SafeClient) SetOutletAutoEnableDelay(n int, delay time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetOutletAutoEnableDelay(n, delay)
+}
+
+// OutletAutoDisableDelay calls Client.OutletAutoDisableDelay while holding the lock
+func (s *SafeClient) OutletAutoDisableDelay(n int) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutletAutoDisableDelay(n)
+}
+
+// SetOutletAutoDisableDelay calls Client.SetOutletAutoDisableDelay while holding the lock
+func (s *SafeClient) SetOutletAutoDisableDelay(n int, delay time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetOutletAutoDisableDelay(n, delay)
+}
+
+// UpsTemperatureUnit calls Client.UpsTemperatureUnit while holding the lock
+func (s *SafeClient) UpsTemperatureUnit() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsTemperatureUnit()
+}
+
+// UpsTemperatureF calls Client.UpsTemperatureF while holding the lock
+func (s *SafeClient) UpsTemperatureF() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsTemperatureF()
+}
+
+// ChargerType calls Client.ChargerType while holding the lock
+func (s *SafeClient) ChargerType() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ChargerType()
+}
+
+// ChargerStatus calls Client.ChargerStatus while holding the lock
+func (s *SafeClient) ChargerStatus() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ChargerStatus()
+}
+
+// IsSmartCharger calls Client.IsSmartCharger while holding the lock
+func (s *SafeClient) IsSmartCharger() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.IsSmartCharger()
+}
+
+// OvertemperatureProtectionActive calls Client.OvertemperatureProtectionActive while holding the lock
+func (s *SafeClient) OvertemperatureProtectionActive() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OvertemperatureProtectionActive()
+}
+
+// UpsTemperatureAlarm calls Client.UpsTemperatureAlarm while holding the lock
+func (s *SafeClient) UpsTemperatureAlarm() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsTemperatureAlarm()
+}
+
+// InputVoltageTHD calls Client.InputVoltageTHD while holding the lock
+func (s *SafeClient) InputVoltageTHD() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputVoltageTHD()
+}
+
+// InputCurrentTHD calls Client.InputCurrentTHD while holding the lock
+func (s *SafeClient) InputCurrentTHD() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputCurrentTHD()
+}
+
+// OutputVoltageTHD calls Client.OutputVoltageTHD while holding the lock
+func (s *SafeClient) OutputVoltageTHD() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputVoltageTHD()
+}
+
+// GetHarmonicDistortionSummary calls Client.GetHarmonicDistortionSummary while holding the lock
+func (s *SafeClient) GetHarmonicDistortionSummary() (*HarmonicDistortion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetHarmonicDistortionSummary()
+}
+
+// ParallelID calls Client.ParallelID while holding the lock
+func (s *SafeClient) ParallelID() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ParallelID()
+}
+
+// ParallelCount calls Client.ParallelCount while holding the lock
+func (s *SafeClient) ParallelCount() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ParallelCount()
+}
+
+// ParallelSyncStatus calls Client.ParallelSyncStatus while holding the lock
+func (s *SafeClient) ParallelSyncStatus() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ParallelSyncStatus()
+}
+
+// IsRedundant calls Client.IsRedundant while holding the lock
+func (s *SafeClient) IsRedundant() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.IsRedundant()
+}
+
+// ModuleCount calls Client.ModuleCount while holding the lock
+func (s *SafeClient) ModuleCount() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ModuleCount()
+}
+
+// ModuleStatus calls Client.ModuleStatus while holding the lock
+func (s *SafeClient) ModuleStatus(n int) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ModuleStatus(n)
+}
+
+// ModulePower calls Client.ModulePower while holding the lock
+func (s *SafeClient) ModulePower(n int) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ModulePower(n)
+}
+
+// ModuleTemperature calls Client.ModuleTemperature while holding the lock
+func (s *SafeClient) ModuleTemperature(n int) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ModuleTemperature(n)
+}
+
+// GetAllModuleInfo calls Client.GetAllModuleInfo while holding the lock
+func (s *SafeClient) GetAllModuleInfo() ([]*ModuleInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetAllModuleInfo()
+}
+
+// ListVarRange calls Client.ListVarRange while holding the lock
+func (s *SafeClient) ListVarRange(variable string) ([]VarRange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ListVarRange(variable)
+}
+
+// OutputVoltageTarget calls Client.OutputVoltageTarget while holding the lock
+func (s *SafeClient) OutputVoltageTarget() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputVoltageTarget()
+}
+
+// SetOutputVoltageTarget calls Client.SetOutputVoltageTarget while holding the lock
+func (s *SafeClient) SetOutputVoltageTarget(voltage float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetOutputVoltageTarget(voltage)
+}
+
+// OutletGroupAutoTransferEnabled calls Client.OutletGroupAutoTransferEnabled while holding the lock
+func (s *SafeClient) OutletGroupAutoTransferEnabled(n int) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutletGroupAutoTransferEnabled(n)
+}
+
+// SetOutletGroupAutoTransfer calls Client.SetOutletGroupAutoTransfer while holding the lock
+func (s *SafeClient) SetOutletGroupAutoTransfer(n int, enabled bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetOutletGroupAutoTransfer(n, enabled)
+}
+
+// SystemTime calls Client.SystemTime while holding the lock
+func (s *SafeClient) SystemTime() (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SystemTime()
+}
+
+// SetSystemTime calls Client.SetSystemTime while holding the lock
+func (s *SafeClient) SetSystemTime(t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetSystemTime(t)
+}
+
+// SurgeProtectionStatus calls Client.SurgeProtectionStatus while holding the lock
+func (s *SafeClient) SurgeProtectionStatus() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SurgeProtectionStatus()
+}
+
+// IsSurgeProtectionOK calls Client.IsSurgeProtectionOK while holding the lock
+func (s *SafeClient) IsSurgeProtectionOK() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.IsSurgeProtectionOK()
+}
+
+// BatteryLastReplaced calls Client.BatteryLastReplaced while holding the lock
+func (s *SafeClient) BatteryLastReplaced() (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryLastReplaced()
+}
+
+// MarkBatteryReplaced calls Client.MarkBatteryReplaced while holding the lock
+func (s *SafeClient) MarkBatteryReplaced() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.MarkBatteryReplaced()
+}
+
+// BatteryAgeWarning calls Client.BatteryAgeWarning while holding the lock
+func (s *SafeClient) BatteryAgeWarning(threshold time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryAgeWarning(threshold)
+}
+
+// OutputWaveformType calls Client.OutputWaveformType while holding the lock
+func (s *SafeClient) OutputWaveformType() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputWaveformType()
+}
+
+// IsPureSineOutput calls Client.IsPureSineOutput while holding the lock
+func (s *SafeClient) IsPureSineOutput() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.IsPureSineOutput()
+}
+
+// ShutdownCountdownSeconds calls Client.ShutdownCountdownSeconds while holding the lock
+func (s *SafeClient) ShutdownCountdownSeconds() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ShutdownCountdownSeconds()
+}
+
+// WaitForShutdown calls Client.WaitForShutdown while holding the lock
+func (s *SafeClient) WaitForShutdown(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.WaitForShutdown(ctx)
+}
+
+// InputQualityStatus calls Client.InputQualityStatus while holding the lock
+func (s *SafeClient) InputQualityStatus() (InputQualityStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputQualityStatus()
+}
+
+// OutputQualityStatus calls Client.OutputQualityStatus while holding the lock
+func (s *SafeClient) OutputQualityStatus() (OutputQualityStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputQualityStatus()
+}
+
+// GetPowerStatus calls Client.GetPowerStatus while holding the lock
+func (s *SafeClient) GetPowerStatus() (*PowerStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetPowerStatus()
+}
+
+// InputVoltageFloat calls Client.InputVoltageFloat while holding the lock
+func (s *SafeClient) InputVoltageFloat() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputVoltageFloat()
+}
+
+// RunInstantCommand calls Client.RunInstantCommand while holding the lock
+func (s *SafeClient) RunInstantCommand(command string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.RunInstantCommand(command)
+}
+
+// ListCommands calls Client.ListCommands while holding the lock
+func (s *SafeClient) ListCommands() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ListCommands()
+}
+
+// TestBattery calls Client.TestBattery while holding the lock
+func (s *SafeClient) TestBattery() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.TestBattery()
+}
+
+// BeeperToggle calls Client.BeeperToggle while holding the lock
+func (s *SafeClient) BeeperToggle() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BeeperToggle()
+}
+
+// ShutdownReturn calls Client.ShutdownReturn while holding the lock
+func (s *SafeClient) ShutdownReturn() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ShutdownReturn()
+}
+
+// GetRWVars calls Client.GetRWVars while holding the lock
+func (s *SafeClient) GetRWVars() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetRWVars()
+}
+
+// SetVar calls Client.SetVar while holding the lock
+func (s *SafeClient) SetVar(variable string, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetVar(variable, value)
+}
+
+// GetRWVarsWithValues calls Client.GetRWVarsWithValues while holding the lock
+func (s *SafeClient) GetRWVarsWithValues() (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetRWVarsWithValues()
+}
+
+// GetVarEnum calls Client.GetVarEnum while holding the lock
+func (s *SafeClient) GetVarEnum(variable string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetVarEnum(variable)
+}
+
+// GetVarRange calls Client.GetVarRange while holding the lock
+func (s *SafeClient) GetVarRange(variable string) (float64, float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetVarRange(variable)
+}
+
+// GetVarType calls Client.GetVarType while holding the lock
+func (s *SafeClient) GetVarType(variable string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetVarType(variable)
+}
+
+// GetVarTypeInfo calls Client.GetVarTypeInfo while holding the lock
+func (s *SafeClient) GetVarTypeInfo(variable string) (VarType, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetVarTypeInfo(variable)
+}
+
+// GetVarDesc calls Client.GetVarDesc while holding the lock
+func (s *SafeClient) GetVarDesc(variable string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetVarDesc(variable)
+}
+
+// GetCmdDesc calls Client.GetCmdDesc while holding the lock
+func (s *SafeClient) GetCmdDesc(command string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetCmdDesc(command)
+}
+
+// GetUpsVarsWithValues calls Client.GetUpsVarsWithValues while holding the lock
+func (s *SafeClient) GetUpsVarsWithValues() (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsVarsWithValues()
+}
+
+// GetUpsSnapshot calls Client.GetUpsSnapshot while holding the lock
+func (s *SafeClient) GetUpsSnapshot() (UpsSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsSnapshot()
+}
+
+// ForcedShutdown calls Client.ForcedShutdown while holding the lock
+func (s *SafeClient) ForcedShutdown() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.ForcedShutdown()
+}
+
+// GetNumLogins calls Client.GetNumLogins while holding the lock
+func (s *SafeClient) GetNumLogins(upsName string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetNumLogins(upsName)
+}
+
+// GetClientList calls Client.GetClientList while holding the lock
+func (s *SafeClient) GetClientList(upsName string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetClientList(upsName)
+}
+
+// GetServerVer calls Client.GetServerVer while holding the lock
+func (s *SafeClient) GetServerVer() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetServerVer()
+}
+
+// GetNetworkProtocolVersion calls Client.GetNetworkProtocolVersion while holding the lock
+func (s *SafeClient) GetNetworkProtocolVersion() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetNetworkProtocolVersion()
+}
+
+// GetServerHelp calls Client.GetServerHelp while holding the lock
+func (s *SafeClient) GetServerHelp() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetServerHelp()
+}
+
+// BatteryRuntimeDuration calls Client.BatteryRuntimeDuration while holding the lock
+func (s *SafeClient) BatteryRuntimeDuration() (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryRuntimeDuration()
+}
+
+// BatteryRuntimeLowDuration calls Client.BatteryRuntimeLowDuration while holding the lock
+func (s *SafeClient) BatteryRuntimeLowDuration() (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryRuntimeLowDuration()
+}
+
+// BatteryRuntimeRestartDuration calls Client.BatteryRuntimeRestartDuration while holding the lock
+func (s *SafeClient) BatteryRuntimeRestartDuration() (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryRuntimeRestartDuration()
+}
+
+// BatteryVoltage calls Client.BatteryVoltage while holding the lock
+func (s *SafeClient) BatteryVoltage() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryVoltage()
+}
+
+// BatteryVoltageNominal calls Client.BatteryVoltageNominal while holding the lock
+func (s *SafeClient) BatteryVoltageNominal() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryVoltageNominal()
+}
+
+// BatteryVoltageLow calls Client.BatteryVoltageLow while holding the lock
+func (s *SafeClient) BatteryVoltageLow() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryVoltageLow()
+}
+
+// BatteryVoltageHigh calls Client.BatteryVoltageHigh while holding the lock
+func (s *SafeClient) BatteryVoltageHigh() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryVoltageHigh()
+}
+
+// BatteryCurrent calls Client.BatteryCurrent while holding the lock
+func (s *SafeClient) BatteryCurrent() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryCurrent()
+}
+
+// BatteryTemperature calls Client.BatteryTemperature while holding the lock
+func (s *SafeClient) BatteryTemperature() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.BatteryTemperature()
+}
+
+// OutputVoltageFloat calls Client.OutputVoltageFloat while holding the lock
+func (s *SafeClient) OutputVoltageFloat() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputVoltageFloat()
+}
+
+// InputCurrentFloat calls Client.InputCurrentFloat while holding the lock
+func (s *SafeClient) InputCurrentFloat() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputCurrentFloat()
+}
+
+// OutputCurrentFloat calls Client.OutputCurrentFloat while holding the lock
+func (s *SafeClient) OutputCurrentFloat() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputCurrentFloat()
+}
+
+// InputFrequencyFloat calls Client.InputFrequencyFloat while holding the lock
+func (s *SafeClient) InputFrequencyFloat() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputFrequencyFloat()
+}
+
+// OutputFrequencyFloat calls Client.OutputFrequencyFloat while holding the lock
+func (s *SafeClient) OutputFrequencyFloat() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputFrequencyFloat()
+}
+
+// InputVoltageMin calls Client.InputVoltageMin while holding the lock
+func (s *SafeClient) InputVoltageMin() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputVoltageMin()
+}
+
+// InputVoltageMax calls Client.InputVoltageMax while holding the lock
+func (s *SafeClient) InputVoltageMax() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputVoltageMax()
+}
+
+// InputVoltageNominal calls Client.InputVoltageNominal while holding the lock
+func (s *SafeClient) InputVoltageNominal() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputVoltageNominal()
+}
+
+// OutputVoltageNominal calls Client.OutputVoltageNominal while holding the lock
+func (s *SafeClient) OutputVoltageNominal() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.OutputVoltageNominal()
+}
+
+// InputTransferHigh calls Client.InputTransferHigh while holding the lock
+func (s *SafeClient) InputTransferHigh() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputTransferHigh()
+}
+
+// InputTransferLow calls Client.InputTransferLow while holding the lock
+func (s *SafeClient) InputTransferLow() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.InputTransferLow()
+}
+
+// SetInputTransferHigh calls Client.SetInputTransferHigh while holding the lock
+func (s *SafeClient) SetInputTransferHigh(v float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetInputTransferHigh(v)
+}
+
+// SetInputTransferLow calls Client.SetInputTransferLow while holding the lock
+func (s *SafeClient) SetInputTransferLow(v float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.SetInputTransferLow(v)
+}
+
+// UpsPowerFactor calls Client.UpsPowerFactor while holding the lock
+func (s *SafeClient) UpsPowerFactor() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsPowerFactor()
+}
+
+// UpsEfficiency calls Client.UpsEfficiency while holding the lock
+func (s *SafeClient) UpsEfficiency() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsEfficiency()
+}
+
+// UpsTemperatureFloat calls Client.UpsTemperatureFloat while holding the lock
+func (s *SafeClient) UpsTemperatureFloat() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsTemperatureFloat()
+}
+
+// GetUpsManufacturer calls Client.GetUpsManufacturer while holding the lock
+func (s *SafeClient) GetUpsManufacturer() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsManufacturer()
+}
+
+// GetUpsFirmwareVersion calls Client.GetUpsFirmwareVersion while holding the lock
+func (s *SafeClient) GetUpsFirmwareVersion() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsFirmwareVersion()
+}
+
+// GetUpsFirmwareAux calls Client.GetUpsFirmwareAux while holding the lock
+func (s *SafeClient) GetUpsFirmwareAux() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsFirmwareAux()
+}
+
+// GetUpsId calls Client.GetUpsId while holding the lock
+func (s *SafeClient) GetUpsId() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsId()
+}
+
+// GetUpsLocation calls Client.GetUpsLocation while holding the lock
+func (s *SafeClient) GetUpsLocation() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsLocation()
+}
+
+// GetUpsContact calls Client.GetUpsContact while holding the lock
+func (s *SafeClient) GetUpsContact() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsContact()
+}
+
+// GetUpsMfrDate calls Client.GetUpsMfrDate while holding the lock
+func (s *SafeClient) GetUpsMfrDate() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsMfrDate()
+}
+
+// GetUpsMfrDateParsed calls Client.GetUpsMfrDateParsed while holding the lock
+func (s *SafeClient) GetUpsMfrDateParsed() (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsMfrDateParsed()
+}
+
+// GetUpsDescription calls Client.GetUpsDescription while holding the lock
+func (s *SafeClient) GetUpsDescription(upsName string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetUpsDescription(upsName)
+}
+
+// LoginSingle calls Client.LoginSingle while holding the lock
+func (s *SafeClient) LoginSingle() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.LoginSingle()
+}
+
+// GetDriverName calls Client.GetDriverName while holding the lock
+func (s *SafeClient) GetDriverName() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetDriverName()
+}
+
+// GetDriverVersion calls Client.GetDriverVersion while holding the lock
+func (s *SafeClient) GetDriverVersion() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetDriverVersion()
+}
+
+// GetServerUpsListWithDesc calls Client.GetServerUpsListWithDesc while holding the lock
+func (s *SafeClient) GetServerUpsListWithDesc() ([]UpsEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.GetServerUpsListWithDesc()
+}
+
+// UpsLoadFloat calls Client.UpsLoadFloat while holding the lock
+func (s *SafeClient) UpsLoadFloat() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsLoadFloat()
+}
+
+// UpsApparentPowerFloat calls Client.UpsApparentPowerFloat while holding the lock
+func (s *SafeClient) UpsApparentPowerFloat() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsApparentPowerFloat()
+}
+
+// UpsActivePowerFloat calls Client.UpsActivePowerFloat while holding the lock
+func (s *SafeClient) UpsActivePowerFloat() (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client.UpsActivePowerFloat()
+}